@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultAPIVersion é a versão da API REST fixada no header
+// X-GitHub-Api-Version. Fixar a versão evita que mudanças incompatíveis
+// do GitHub mudem o comportamento da ferramenta sem aviso.
+const defaultAPIVersion = "2022-11-28"
+
+// deprecationWarnings guarda os avisos de obsolescência já mostrados, para
+// que cada um apareça uma vez só, mesmo com muitas requisições.
+type deprecationWarnings struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// first informa se key ainda não tinha sido avisada (e a marca).
+func (d *deprecationWarnings) first(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.seen == nil {
+		d.seen = map[string]bool{}
+	}
+	if d.seen[key] {
+		return false
+	}
+	d.seen[key] = true
+	return true
+}
+
+// WithAPIVersion fixa a versão da API (formato AAAA-MM-DD) enviada em
+// X-GitHub-Api-Version; vazio deixa de enviar o header e o GitHub usa a
+// versão padrão dele.
+func WithAPIVersion(v string) ClientOption {
+	return func(c *Client) error {
+		if v != "" {
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return fmt.Errorf(tr("versão da API inválida: %q (use o formato AAAA-MM-DD)"), v)
+			}
+		}
+		c.apiVersion = v
+		return nil
+	}
+}
+
+// parseDeprecationDate interpreta os formatos de data dos headers
+// Deprecation (@<unix>, pelo RFC 9745, ou data HTTP) e Sunset (data HTTP).
+// O valor "true" e datas ilegíveis devolvem o tempo zero.
+func parseDeprecationDate(v string) time.Time {
+	v = strings.TrimSpace(v)
+	if unix, ok := strings.CutPrefix(v, "@"); ok {
+		if n, err := strconv.ParseInt(unix, 10, 64); err == nil {
+			return time.Unix(n, 0).UTC()
+		}
+		return time.Time{}
+	}
+	t, _ := http.ParseTime(v)
+	return t
+}
+
+// checkDeprecation avisa, no log, quando o GitHub sinaliza nos headers
+// Deprecation e Sunset que o endpoint ou a versão fixada da API vão
+// deixar de existir. Cada combinação de headers é avisada uma vez, com o
+// primeiro caminho em que apareceu.
+func (c *Client) checkDeprecation(req *http.Request, resp *http.Response) {
+	deprecation := resp.Header.Get("Deprecation")
+	sunset := resp.Header.Get("Sunset")
+	if deprecation == "" && sunset == "" {
+		return
+	}
+	if !c.deprecations.first(c.apiVersion + "|" + deprecation + "|" + sunset) {
+		return
+	}
+
+	version := c.apiVersion
+	if version == "" {
+		version = tr("padrão do GitHub")
+	}
+	msg := fmt.Sprintf(tr("aviso: o GitHub marcou %s (API versão %s) como obsoleto"), req.URL.Path, version)
+	if t := parseDeprecationDate(deprecation); !t.IsZero() {
+		msg += fmt.Sprintf(tr(" desde %s"), t.Format("2006-01-02"))
+	}
+	if t := parseDeprecationDate(sunset); !t.IsZero() {
+		msg += fmt.Sprintf(tr("; desativação prevista para %s"), t.Format("2006-01-02"))
+	}
+	if link := deprecationLink(resp.Header); link != "" {
+		msg += fmt.Sprintf(" (%s)", link)
+	}
+	log.Print(msg)
+}
+
+// deprecationLink devolve a URL do header Link com rel="deprecation" ou
+// rel="sunset", que aponta para o aviso de mudança, se houver.
+func deprecationLink(h http.Header) string {
+	rels := parseLinkHeader(h.Values("Link")...)
+	if link := rels["deprecation"]; link != "" {
+		return link
+	}
+	return rels["sunset"]
+}
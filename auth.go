@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Endpoints do fluxo de autorização por dispositivo (OAuth device flow).
+// Eles ficam em github.com, e não em api.github.com.
+const (
+	deviceCodeURL  = "https://github.com/login/device/code"
+	accessTokenURL = "https://github.com/login/oauth/access_token"
+)
+
+// deviceCode é a resposta do primeiro passo do device flow.
+type deviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// accessToken é a resposta do polling; enquanto o usuário não autoriza,
+// o GitHub devolve apenas o campo Error (ex: "authorization_pending").
+type accessToken struct {
+	AccessToken string `json:"access_token"`
+	Scope       string `json:"scope"`
+	Error       string `json:"error"`
+	ErrorDesc   string `json:"error_description"`
+}
+
+// resolveToken define qual token usar: a variável GITHUB_TOKEN tem
+// prioridade; depois vem o token salvo pelo subcomando login.
+// Sem nenhum dos dois, as chamadas são anônimas.
+func resolveToken() string {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+	token, err := loadToken()
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+// runLogin implementa o subcomando `login`.
+func runLogin(args []string) error {
+	fs := newFlagSet("login")
+	clientID := fs.String("client-id", os.Getenv("GHSEARCH_CLIENT_ID"), "Client ID do OAuth App (ou GHSEARCH_CLIENT_ID)")
+	scopes := fs.String("scopes", "read:user", "escopos solicitados, separados por espaço")
+	fs.Parse(args)
+
+	if *clientID == "" {
+		return errors.New(tr("informe o Client ID do OAuth App com -client-id ou GHSEARCH_CLIENT_ID"))
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	ctx := context.Background()
+
+	code, err := requestDeviceCode(ctx, client, *clientID, *scopes)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(tr("Abra %s e digite o código: %s\n"), code.VerificationURI, code.UserCode)
+	fmt.Println(tr("Aguardando autorização..."))
+
+	token, err := pollAccessToken(ctx, client, *clientID, code)
+	if err != nil {
+		return err
+	}
+
+	where, err := saveToken(token.AccessToken)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(tr("Login concluído (escopos: %q). Token salvo em: %s\n"), token.Scope, where)
+	return nil
+}
+
+// requestDeviceCode inicia o device flow e obtém o código a ser
+// digitado pelo usuário no navegador.
+func requestDeviceCode(ctx context.Context, client Doer, clientID, scopes string) (*deviceCode, error) {
+	form := url.Values{}
+	form.Set("client_id", clientID)
+	form.Set("scope", scopes)
+
+	var code deviceCode
+	if err := postForm(ctx, client, deviceCodeURL, form, &code); err != nil {
+		return nil, err
+	}
+	if code.DeviceCode == "" {
+		return nil, errors.New(tr("GitHub não devolveu um device_code; verifique se o device flow está habilitado no OAuth App"))
+	}
+	return &code, nil
+}
+
+// pollAccessToken consulta o GitHub no intervalo indicado até o usuário
+// autorizar, negar ou o código expirar.
+func pollAccessToken(ctx context.Context, client Doer, clientID string, code *deviceCode) (*accessToken, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	form := url.Values{}
+	form.Set("client_id", clientID)
+	form.Set("device_code", code.DeviceCode)
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		var token accessToken
+		if err := postForm(ctx, client, accessTokenURL, form, &token); err != nil {
+			return nil, err
+		}
+
+		switch token.Error {
+		case "":
+			return &token, nil
+		case "authorization_pending":
+			// Usuário ainda não autorizou; continua aguardando.
+		case "slow_down":
+			// O GitHub pede para aumentarmos o intervalo em 5s.
+			interval += 5 * time.Second
+		default:
+			return nil, fmt.Errorf(tr("login recusado pelo GitHub: %s (%s)"), token.Error, token.ErrorDesc)
+		}
+	}
+	return nil, errors.New(tr("o código de autorização expirou; execute login novamente"))
+}
+
+// postForm envia um formulário e decodifica a resposta JSON em v.
+func postForm(ctx context.Context, client Doer, endpoint string, form url.Values, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf(tr("falha ao criar requisição: %w"), err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", defaultUserAgent())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf(tr("falha ao executar requisição: %w"), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf(tr("GitHub retornou status não-OK: %s"), resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf(tr("falha ao decodificar JSON: %w"), err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// awesomeCategory é uma seção da lista: um cluster de repositórios
+// parecidos, com o nome tirado das palavras do cluster.
+type awesomeCategory struct {
+	Name  string
+	Repos []Repository
+}
+
+// queryWords devolve as palavras dos tópicos e do texto livre da query.
+// Elas aparecem em todos os resultados, então não distinguem categorias.
+func queryWords(query string) map[string]bool {
+	words := map[string]bool{}
+	terms, _ := splitQuery(query)
+	for _, term := range terms {
+		switch qualifierName(term) {
+		case "topic", "topics":
+			_, term, _ = strings.Cut(term, ":")
+		case "":
+		default:
+			continue
+		}
+		for _, w := range strings.FieldsFunc(strings.ToLower(term), func(c rune) bool {
+			return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+		}) {
+			words[w] = true
+		}
+	}
+	return words
+}
+
+// awesomeCategories agrupa os repositórios com clusterRepos, ignorando os
+// tópicos da própria query. Cada categoria sai ordenada por estrelas; as
+// sem nome (repositórios sem similares) vão juntas para "Outros", no fim.
+func awesomeCategories(repos []Repository, query string, threshold float64) []awesomeCategory {
+	ignore := queryWords(query)
+	stripped := make([]Repository, len(repos))
+	for i, r := range repos {
+		r.Topics = nil
+		for _, t := range repos[i].Topics {
+			if !ignore[t] {
+				r.Topics = append(r.Topics, t)
+			}
+		}
+		stripped[i] = r
+	}
+	original := map[string]Repository{}
+	for _, r := range repos {
+		original[r.FullName] = r
+	}
+
+	var categories []awesomeCategory
+	var other []Repository
+	for _, c := range clusterRepos(stripped, threshold) {
+		var label []string
+		for _, w := range c.Label {
+			if !ignore[w] {
+				label = append(label, titleCase(w))
+			}
+		}
+		members := make([]Repository, len(c.Repos))
+		for i, r := range c.Repos {
+			members[i] = original[r.FullName]
+		}
+		if len(label) == 0 {
+			other = append(other, members...)
+			continue
+		}
+		categories = append(categories, awesomeCategory{Name: strings.Join(label, ", "), Repos: members})
+	}
+	if len(other) > 0 {
+		categories = append(categories, awesomeCategory{Name: tr("Outros"), Repos: other})
+	}
+	for _, c := range categories {
+		sort.SliceStable(c.Repos, func(a, b int) bool {
+			return c.Repos[a].Stars > c.Repos[b].Stars
+		})
+	}
+	return categories
+}
+
+// titleCase põe a primeira letra em maiúscula.
+func titleCase(s string) string {
+	for i, r := range s {
+		return string(unicode.ToUpper(r)) + s[i+len(string(r)):]
+	}
+	return s
+}
+
+// awesomeTitle deriva o título da lista do primeiro tópico da query (ex:
+// topic:observability vira "Awesome Observability").
+func awesomeTitle(query string) string {
+	terms, _ := splitQuery(query)
+	for _, term := range terms {
+		if qualifierName(term) == "topic" {
+			_, topic, _ := strings.Cut(term, ":")
+			return "Awesome " + titleCase(strings.ReplaceAll(topic, "-", " "))
+		}
+	}
+	return "Awesome " + query
+}
+
+// markdownAnchor reproduz o id que o GitHub gera para um título.
+func markdownAnchor(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// writeAwesome escreve a lista no formato dos repositórios awesome-*:
+// título com o badge, sumário e uma seção por categoria.
+func writeAwesome(w io.Writer, title, query string, categories []awesomeCategory, now time.Time) {
+	fmt.Fprintf(w, "# %s [![Awesome](https://awesome.re/badge.svg)](https://awesome.re)\n\n", title)
+	fmt.Fprintf(w, tr("> Lista gerada a partir da busca `%s` em %s.\n\n"), query, now.Format("2006-01-02"))
+
+	fmt.Fprint(w, tr("## Conteúdo\n\n"))
+	for _, c := range categories {
+		fmt.Fprintf(w, "- [%s](#%s)\n", c.Name, markdownAnchor(c.Name))
+	}
+
+	for _, c := range categories {
+		fmt.Fprintf(w, "\n## %s\n\n", c.Name)
+		for _, r := range c.Repos {
+			fmt.Fprintf(w, "- [%s](%s) ![%s](https://img.shields.io/github/stars/%s?style=flat)", r.FullName, r.URL, tr("estrelas"), r.FullName)
+			if desc := strings.TrimSpace(r.Description); desc != "" {
+				if !strings.HasSuffix(desc, ".") {
+					desc += "."
+				}
+				fmt.Fprintf(w, " - %s", desc)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// runAwesome implementa `awesome -q "..."`: busca os repositórios e gera
+// uma lista awesome em Markdown, agrupada por categorias.
+func runAwesome(args []string) error {
+	fs := newFlagSet("awesome")
+	sf := addSearchFlags(fs, 100)
+	title := fs.String("title", "", "título da lista (padrão: derivado do primeiro topic: da query)")
+	out := fs.String("out", "", "arquivo de saída (padrão: saída padrão)")
+	minStars := fs.Int("min-stars", 10, "ignora repositórios com menos estrelas")
+	threshold := fs.Float64("cluster-threshold", defaultClusterThreshold, "similaridade mínima (0..1) para dois repositórios ficarem na mesma categoria")
+	cf := addClientFlags(fs)
+	fs.Parse(args)
+
+	if err := sf.validate(); err != nil {
+		return err
+	}
+	if err := validateQuery(sf.query); err != nil {
+		return err
+	}
+	if *threshold <= 0 || *threshold > 1 {
+		return invalid(errors.New(tr("-cluster-threshold deve estar entre 0 e 1")))
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := cf.context()
+	defer cancel()
+
+	result, err := client.SearchAllRepositories(ctx, sf.options(), sf.limit)
+	if err != nil {
+		return err
+	}
+	// Projetos arquivados não entram numa lista recomendada.
+	var repos []Repository
+	for _, r := range result.Items {
+		if !r.Archived && r.Stars >= *minStars {
+			repos = append(repos, r)
+		}
+	}
+	if *title == "" {
+		*title = awesomeTitle(sf.query)
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf(tr("falha ao criar %s: %w"), *out, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	writeAwesome(w, *title, sf.query, awesomeCategories(repos, sf.query, *threshold), time.Now())
+	if *out != "" {
+		fmt.Fprintf(os.Stderr, tr("%d repositórios em %s\n"), len(repos), *out)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// batchResult é o resultado de uma query do lote.
+type batchResult struct {
+	Query  string
+	Result *SearchResult
+}
+
+// readQueries lê uma query por linha de path ("-" para stdin). Linhas
+// vazias e comentários iniciados por # são ignorados.
+func readQueries(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf(tr("falha ao abrir arquivo de queries: %w"), err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var queries []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		queries = append(queries, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf(tr("falha ao ler queries: %w"), err)
+	}
+	if len(queries) == 0 {
+		return nil, errors.New(tr("o arquivo de queries está vazio"))
+	}
+	return queries, nil
+}
+
+// runBatch executa o job para cada query, com até concurrency queries em
+// paralelo. O ritmo das chamadas continua controlado pelo rate limiter do
+// cliente, então mais concorrência só adianta enquanto houver quota. Em
+// paralelo, as fases de cada query disputariam a mesma linha de
+// progresso; o indicador mostra então o lote, uma query por vez.
+func runBatch(ctx context.Context, client *Client, job searchJob, queries []string, concurrency int) ([]batchResult, error) {
+	concurrency = max(concurrency, 1)
+	results := make([]batchResult, len(queries))
+	errs := make([]error, len(queries))
+
+	jobCtx, batchProg := ctx, (*progress)(nil)
+	if concurrency > 1 {
+		jobCtx, batchProg = withoutProgress(ctx), client.progressFor(ctx)
+		batchProg.Start(tr("lote"), len(queries), tr("queries"))
+		defer batchProg.Finish()
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, q := range queries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result, err := job.run(jobCtx, client, q)
+			batchProg.Step(0)
+			if err != nil {
+				errs[i] = fmt.Errorf("query %q: %w", q, err)
+				return
+			}
+			results[i] = batchResult{Query: q, Result: result}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// combineResults une os resultados do lote sem repetir repositórios,
+// do mais estrelado para o menos.
+func combineResults(results []batchResult) *SearchResult {
+	seen := map[string]bool{}
+	combined := &SearchResult{}
+	for _, br := range results {
+		for _, repo := range br.Result.Items {
+			if !seen[repo.FullName] {
+				seen[repo.FullName] = true
+				combined.Items = append(combined.Items, repo)
+			}
+		}
+	}
+	sort.SliceStable(combined.Items, func(a, b int) bool {
+		return combined.Items[a].Stars > combined.Items[b].Stars
+	})
+	combined.TotalCount = len(combined.Items)
+	return combined
+}
+
+// writeBatch escreve o lote. Com outDir, cada query vira um arquivo e o
+// combinado vai para combined.<ext>; sem ele, tudo sai em w.
+func writeBatch(w io.Writer, outDir, format string, results []batchResult, fields []field) error {
+	combined := combineResults(results)
+
+	if outDir != "" {
+		if err := os.MkdirAll(outDir, 0o755); err != nil {
+			return fmt.Errorf(tr("falha ao criar diretório de saída: %w"), err)
+		}
+		for i, br := range results {
+			name := fmt.Sprintf("%02d-%s.%s", i+1, querySlug(br.Query), formatExt(format))
+			if err := writeResultFile(filepath.Join(outDir, name), format, br.Result, fields); err != nil {
+				return err
+			}
+		}
+		return writeResultFile(filepath.Join(outDir, "combined."+formatExt(format)), format, combined, fields)
+	}
+
+	switch format {
+	case formatJSON:
+		type query struct {
+			Query string `json:"query"`
+			jsonResult
+		}
+		out := struct {
+			Queries  []query    `json:"queries"`
+			Combined jsonResult `json:"combined"`
+		}{Combined: newJSONResult(combined, fields)}
+		for _, br := range results {
+			out.Queries = append(out.Queries, query{br.Query, newJSONResult(br.Result, fields)})
+		}
+		return encodeJSON(w, out)
+	case formatCSV:
+		return errors.New(tr("CSV em lote precisa de -out-dir (um arquivo por query)"))
+	}
+
+	for _, br := range results {
+		fmt.Fprintf(w, "=== %s ===\n", br.Query)
+		if err := writeResults(w, format, br.Result, fields); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, tr("=== combinado ==="))
+	return writeResults(w, format, combined, fields)
+}
+
+func writeResultFile(path, format string, result *SearchResult, fields []field) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf(tr("falha ao criar %s: %w"), path, err)
+	}
+	if err := writeResults(f, format, result, fields); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// querySlug gera um trecho de nome de arquivo a partir da query.
+func querySlug(q string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(q) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	slug := strings.Trim(b.String(), "-")
+	if len(slug) > 40 {
+		slug = strings.TrimRight(slug[:40], "-")
+	}
+	if slug == "" {
+		slug = "query"
+	}
+	return slug
+}
+
+// formatExt devolve a extensão de arquivo de cada formato de saída.
+func formatExt(format string) string {
+	switch format {
+	case formatCSV:
+		return "csv"
+	case formatJSON:
+		return "json"
+	}
+	return "txt"
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Benchmarks do caminho decodificação → filtros → ranking → paginação,
+// com dados sintéticos do tamanho máximo de uma busca (1000 resultados).
+// Para comparar uma mudança, rode antes e depois e compare com benchstat:
+//
+//	go test -run '^$' -bench . -benchmem -count 10 > antes.txt
+
+// benchNow fixa o "agora" dos filtros e rankers, para que os resultados
+// não dependam do dia em que o benchmark roda.
+var benchNow = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+var benchLanguages = []string{"Go", "Rust", "Python", "TypeScript", "C", ""}
+var benchTopics = []string{"cli", "http", "database", "kubernetes", "parser", "web", "testing", "hacktoberfest"}
+
+// syntheticRepos gera n repositórios com a forma dos da busca: datas,
+// licença, tópicos e contagens espalhados. A semente fixa mantém os dados
+// iguais entre execuções.
+func syntheticRepos(n int) []Repository {
+	rng := rand.New(rand.NewPCG(1, 2))
+	repos := make([]Repository, n)
+	for i := range repos {
+		owner := fmt.Sprintf("owner%d", rng.IntN(n/4+1))
+		name := fmt.Sprintf("repo-%d", i)
+		created := benchNow.AddDate(0, 0, -rng.IntN(4000)-30)
+		pushed := benchNow.Add(-time.Duration(rng.IntN(2000)) * time.Hour)
+		topics := make([]string, rng.IntN(5))
+		for j := range topics {
+			topics[j] = benchTopics[rng.IntN(len(benchTopics))]
+		}
+		r := Repository{
+			Name:          name,
+			FullName:      owner + "/" + name,
+			Owner:         Owner{Login: owner, Type: "User"},
+			URL:           "https://github.com/" + owner + "/" + name,
+			Description:   "Um projeto sintético para benchmarks, com uma descrição de tamanho realista.",
+			Language:      benchLanguages[rng.IntN(len(benchLanguages))],
+			Stars:         rng.IntN(100000),
+			Forks:         rng.IntN(10000),
+			Watchers:      rng.IntN(100000),
+			OpenIssues:    rng.IntN(500),
+			Size:          rng.IntN(200000),
+			DefaultBranch: "main",
+			CreatedAt:     created,
+			UpdatedAt:     pushed,
+			PushedAt:      pushed,
+			Archived:      rng.IntN(20) == 0,
+			Topics:        topics,
+			Score:         rng.Float64(),
+		}
+		if rng.IntN(4) > 0 {
+			r.License = &License{Key: "mit", Name: "MIT License", SPDXID: "MIT"}
+		}
+		repos[i] = r
+	}
+	return repos
+}
+
+// searchPageJSON serializa repos como uma página de /search/repositories.
+func searchPageJSON(tb testing.TB, total int, repos []Repository) []byte {
+	tb.Helper()
+	data, err := json.Marshal(struct {
+		TotalCount int          `json:"total_count"`
+		Incomplete bool         `json:"incomplete_results"`
+		Items      []Repository `json:"items"`
+	}{total, false, repos})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return data
+}
+
+func BenchmarkDecodeSearchPage(b *testing.B) {
+	for _, n := range []int{30, maxPerPage} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			data := searchPageJSON(b, n, syntheticRepos(n))
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for b.Loop() {
+				var result SearchResult
+				if err := json.Unmarshal(data, &result); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFilters(b *testing.B) {
+	repos := syntheticRepos(maxSearchResults)
+	dates := dateFilter{PushedWithin: 30 * 24 * time.Hour, CreatedWithin: 5 * 365 * 24 * time.Hour}
+	topics := topicFilter{Include: []string{"cli"}, Exclude: []string{"hacktoberfest"}}
+	b.ReportAllocs()
+	for b.Loop() {
+		kept := filterByDate(repos, dates, benchNow)
+		kept = filterByTopic(kept, topics)
+		kept = filterByScore(kept, 0.2)
+		kept = filterBySize(kept, 100000)
+		_ = filterActive(kept, 90*24*time.Hour, benchNow)
+	}
+}
+
+func BenchmarkRank(b *testing.B) {
+	repos := syntheticRepos(maxSearchResults)
+	weighted, err := parseRankWeights("stars=0.5,recency=0.3,health=0.2")
+	if err != nil {
+		b.Fatal(err)
+	}
+	cases := map[string]Ranker{"weighted": weighted}
+	for name, r := range rankers {
+		cases[name] = r
+	}
+	for name, ranker := range cases {
+		b.Run(name, func(b *testing.B) {
+			// Rank ordena no lugar: cada rodada parte da ordem original.
+			work := make([]Repository, len(repos))
+			b.ReportAllocs()
+			for b.Loop() {
+				copy(work, repos)
+				ranker.Rank(work, benchNow)
+			}
+		})
+	}
+}
+
+// pageDoer serve páginas de busca já serializadas, com o header Link, sem
+// rede: o benchmark mede só o cliente.
+type pageDoer struct {
+	pages [][]byte
+}
+
+func (d pageDoer) Do(req *http.Request) (*http.Response, error) {
+	page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+	if page < 1 || page > len(d.pages) {
+		return (&cassette{StatusCode: http.StatusUnprocessableEntity, Header: http.Header{}, Body: `{"message":"Validation Failed"}`}).response(req), nil
+	}
+	header := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+	if page < len(d.pages) {
+		next, last := *req.URL, *req.URL
+		q := next.Query()
+		q.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = q.Encode()
+		q.Set("page", strconv.Itoa(len(d.pages)))
+		last.RawQuery = q.Encode()
+		header.Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, next.String(), last.String()))
+	}
+	return (&cassette{StatusCode: http.StatusOK, Header: header, Body: string(d.pages[page-1])}).response(req), nil
+}
+
+func BenchmarkSearchAllRepositories(b *testing.B) {
+	repos := syntheticRepos(maxSearchResults)
+	var doer pageDoer
+	var size int64
+	for from := 0; from < len(repos); from += maxPerPage {
+		page := searchPageJSON(b, 250000, repos[from:min(from+maxPerPage, len(repos))])
+		doer.pages = append(doer.pages, page)
+		size += int64(len(page))
+	}
+	c, err := NewClient(WithHTTPClient(doer))
+	if err != nil {
+		b.Fatal(err)
+	}
+	c.SetProgress(quietProgress())
+	opts := SearchOptions{Query: "language:go", Sort: "stars", Order: "desc", PerPage: maxPerPage}
+
+	b.SetBytes(size)
+	b.ReportAllocs()
+	for b.Loop() {
+		result, err := c.SearchAllRepositories(context.Background(), opts, maxSearchResults)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(result.Items) != maxSearchResults {
+			b.Fatalf("%d itens, quer %d", len(result.Items), maxSearchResults)
+		}
+	}
+}
+
+// TestRankAllocsDoNotGrow protege o ranking contra alocações por
+// repositório: ordenar 1000 não pode alocar mais que ordenar 100.
+func TestRankAllocsDoNotGrow(t *testing.T) {
+	small, large := syntheticRepos(100), syntheticRepos(maxSearchResults)
+	for name, ranker := range rankers {
+		allocs := func(repos []Repository) float64 {
+			work := make([]Repository, len(repos))
+			return testing.AllocsPerRun(5, func() {
+				copy(work, repos)
+				ranker.Rank(work, benchNow)
+			})
+		}
+		if s, l := allocs(small), allocs(large); l > s {
+			t.Errorf("rank %s: %.0f alocações com 100 repositórios, %.0f com 1000", name, s, l)
+		}
+	}
+}
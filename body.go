@@ -0,0 +1,119 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// defaultMaxBodySize é o maior corpo de resposta aceito (já descomprimido).
+// Uma página de busca com 100 itens tem poucas centenas de KB; o limite só
+// existe para conter respostas patológicas, principalmente no modo servidor.
+const defaultMaxBodySize = 16 << 20
+
+// errBodyTooLarge indica que a resposta passou do limite de tamanho.
+type errBodyTooLarge struct {
+	Limit int64
+}
+
+func (e *errBodyTooLarge) Error() string {
+	return fmt.Sprintf(tr("resposta excede o limite de %d bytes"), e.Limit)
+}
+
+// limitedBody lê até limit bytes e falha (em vez de truncar em silêncio,
+// o que viraria um JSON inválido difícil de diagnosticar) se houver mais.
+type limitedBody struct {
+	rc        io.ReadCloser
+	limit     int64
+	remaining int64
+}
+
+// limitBody envolve rc com o limite de tamanho. limit <= 0 não limita.
+func limitBody(rc io.ReadCloser, limit int64) io.ReadCloser {
+	if limit <= 0 {
+		return rc
+	}
+	if lb, ok := rc.(*limitedBody); ok && lb.limit <= limit {
+		return lb // já limitado por uma camada de baixo
+	}
+	return &limitedBody{rc: rc, limit: limit, remaining: limit}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, &errBodyTooLarge{b.limit}
+	}
+	// Lê um byte além do limite para distinguir "exatamente no limite" de
+	// "passou do limite".
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.rc.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n - 1, &errBodyTooLarge{b.limit}
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.rc.Close()
+}
+
+// gzipBody descomprime o corpo e, ao fechar, fecha também o original.
+type gzipBody struct {
+	*gzip.Reader
+	raw io.Closer
+}
+
+func (b gzipBody) Close() error {
+	b.Reader.Close()
+	return b.raw.Close()
+}
+
+// decodeBody devolve o corpo da resposta descomprimido (quando veio com
+// Content-Encoding: gzip) e limitado a limit bytes. Os headers são
+// ajustados para refletir o corpo descomprimido.
+func decodeBody(resp *http.Response, limit int64) (io.ReadCloser, error) {
+	body := resp.Body
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf(tr("falha ao descomprimir resposta: %w"), err)
+		}
+		body = gzipBody{zr, resp.Body}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+	return limitBody(body, limit), nil
+}
+
+// decodingTransport pede respostas comprimidas com gzip e as entrega
+// descomprimidas e limitadas às camadas de cima (cache e gravador, que
+// guardam o corpo como texto). Ao definir Accept-Encoding nós mesmos, o
+// http.Transport deixa de descomprimir por conta própria.
+type decodingTransport struct {
+	next    http.RoundTripper
+	maxBody int64
+}
+
+func (t *decodingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := decodeBody(resp, t.maxBody)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	resp.Body = body
+	return resp, nil
+}
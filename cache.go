@@ -0,0 +1,434 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// normalizeQuery reescreve uma query de busca de forma canônica, para que
+// variações equivalentes ("Language:Go  stars:>10" e "stars:>10 language:Go")
+// caiam na mesma entrada de cache: espaços extras são removidos, os nomes
+// dos qualificadores vão para minúsculas e os qualificadores são ordenados.
+// Os termos livres mantêm a ordem original, pois ela afeta a relevância, e
+// um "nome:valor" dentro de aspas é texto livre, não qualificador.
+func normalizeQuery(q string) string {
+	var terms, qualifiers []string
+	for _, tok := range queryTerms(q) {
+		if name, value, ok := strings.Cut(tok, ":"); ok && name != "" && !strings.HasPrefix(name, "\"") {
+			qualifiers = append(qualifiers, strings.ToLower(name)+":"+value)
+			continue
+		}
+		terms = append(terms, tok)
+	}
+	sort.Strings(qualifiers)
+	return strings.Join(append(terms, qualifiers...), " ")
+}
+
+// normalizeURL produz a chave de cache de uma URL: parâmetros com espaços
+// aparados, em ordem alfabética e com a query de busca normalizada.
+func normalizeURL(u *url.URL) string {
+	values := url.Values{}
+	for key, vals := range u.Query() {
+		key = strings.ToLower(strings.TrimSpace(key))
+		for _, v := range vals {
+			v = strings.TrimSpace(v)
+			if key == "q" {
+				v = normalizeQuery(v)
+			}
+			values.Add(key, v)
+		}
+	}
+	n := *u
+	n.RawQuery = values.Encode() // Encode ordena as chaves
+	n.Fragment = ""
+	return n.String()
+}
+
+// cacheEntry é uma resposta guardada em disco. Class decide a validade
+// (veja CachePolicy); entradas antigas, sem classe, valem como 200.
+type cacheEntry struct {
+	cassette
+	StoredAt time.Time     `json:"stored_at"`
+	Class    responseClass `json:"class,omitempty"`
+}
+
+// responseClass separa as respostas cacheáveis conforme a utilidade de
+// guardá-las: resultados normais, resultados vazios e queries rejeitadas.
+type responseClass string
+
+const (
+	classOK      responseClass = ""
+	classEmpty   responseClass = "empty"
+	classInvalid responseClass = "invalid"
+)
+
+// CachePolicy define por quanto tempo cada classe de resposta fica no
+// cache; 0 deixa de guardar a classe. Vazios e 422 valem menos que os
+// resultados normais: uma query recém-criada pode passar a ter resultados,
+// mas repetir a mesma query inválida a cada rodada do daemon, ou a cada
+// requisição ao serve, só gasta quota.
+type CachePolicy struct {
+	OK      time.Duration // 200 com resultados
+	Empty   time.Duration // 200 sem resultados (busca com total_count 0 ou lista vazia)
+	Invalid time.Duration // 422 Unprocessable Entity (query inválida)
+}
+
+// Validades padrão das respostas negativas, limitadas pela de OK.
+const (
+	defaultEmptyTTL   = time.Minute
+	defaultInvalidTTL = 30 * time.Second
+)
+
+// defaultCachePolicy é a política de WithCache(ttl): ttl para os
+// resultados e validades curtas para as respostas negativas.
+func defaultCachePolicy(ttl time.Duration) CachePolicy {
+	return CachePolicy{OK: ttl, Empty: min(ttl, defaultEmptyTTL), Invalid: min(ttl, defaultInvalidTTL)}
+}
+
+func (p CachePolicy) ttl(class responseClass) time.Duration {
+	switch class {
+	case classEmpty:
+		return p.Empty
+	case classInvalid:
+		return p.Invalid
+	}
+	return p.OK
+}
+
+// enabled informa se alguma classe é guardada.
+func (p CachePolicy) enabled() bool {
+	return p.OK > 0 || p.Empty > 0 || p.Invalid > 0
+}
+
+// classify devolve a classe da resposta e se ela pode ir para o cache.
+func classify(status int, body []byte) (responseClass, bool) {
+	switch status {
+	case http.StatusOK:
+		if isEmptyResult(body) {
+			return classEmpty, true
+		}
+		return classOK, true
+	case http.StatusUnprocessableEntity:
+		return classInvalid, true
+	}
+	return "", false
+}
+
+// isEmptyResult reconhece as respostas sem nada: uma lista vazia ou uma
+// busca com total_count 0.
+func isEmptyResult(body []byte) bool {
+	body = bytes.TrimSpace(body)
+	if bytes.Equal(body, []byte("[]")) {
+		return true
+	}
+	if len(body) == 0 || body[0] != '{' {
+		return false
+	}
+	var search struct {
+		TotalCount *int `json:"total_count"`
+	}
+	return json.Unmarshal(body, &search) == nil && search.TotalCount != nil && *search.TotalCount == 0
+}
+
+// cacheStats são os contadores persistidos em stats.json.
+type cacheStats struct {
+	Hits   int `json:"hits"`
+	Misses int `json:"misses"`
+}
+
+// cachingTransport guarda respostas 200 e 422 de GETs em disco, pelo
+// tempo que policy dá à classe de cada uma. /rate_limit e os endpoints do
+// usuário autenticado (/user, /user/starred...) nunca são guardados: só
+// servem se estiverem atualizados, e mudam com um login ou um -star-top.
+type cachingTransport struct {
+	dir    string
+	policy CachePolicy
+	next   http.RoundTripper
+
+	// identity separa as respostas de contas diferentes (veja cacheKey).
+	identity string
+
+	mu    sync.Mutex
+	stats cacheStats
+}
+
+// cacheDir devolve (e cria) o diretório do cache, ex: ~/.cache/ghsearch.
+func cacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf(tr("falha ao localizar diretório de cache: %w"), err)
+	}
+	dir := filepath.Join(base, appName, "http")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf(tr("falha ao criar diretório de cache: %w"), err)
+	}
+	return dir, nil
+}
+
+func newCachingTransport(policy CachePolicy, partition string, next http.RoundTripper) (*cachingTransport, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, err
+	}
+	if partition != "" {
+		dir = filepath.Join(dir, "tenants", partition)
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf(tr("falha ao criar diretório de cache: %w"), err)
+		}
+	}
+	t := &cachingTransport{dir: dir, policy: policy, next: next}
+	t.stats, _ = readCacheStats(dir)
+	return t, nil
+}
+
+// cacheKey considera o Accept, pois o mesmo endpoint responde JSON ou
+// conteúdo bruto conforme o media type pedido, e, fora da busca, a
+// identidade de quem pergunta (veja cacheIdentity), pois o resto da API
+// pode responder conforme a conta (repositórios privados). A busca
+// pública é a mesma para qualquer token e não se divide.
+func cacheKey(req *http.Request, identity string) string {
+	if strings.Contains(req.URL.Path, "/search/") {
+		identity = ""
+	}
+	sum := sha256.Sum256([]byte(req.Method + " " + normalizeURL(req.URL) + " " + req.Header.Get("Accept") + " " + identity))
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheIdentity identifica no cache as credenciais de um Client sem
+// guardá-las: um token só, ou o conjunto dos tokens de um pool, para que
+// o rodízio entre eles não mude a chave. Sem token, é vazia.
+func cacheIdentity(tokens ...string) string {
+	var set []string
+	for _, t := range tokens {
+		if t != "" {
+			set = append(set, t)
+		}
+	}
+	if len(set) == 0 {
+		return ""
+	}
+	sort.Strings(set)
+	return tenantID(strings.Join(set, "\n"))
+}
+
+// uncacheable informa se o caminho fica fora do cache (veja
+// cachingTransport), também atrás da raiz /api/v3 do GitHub Enterprise.
+func uncacheable(path string) bool {
+	path = strings.TrimPrefix(path, "/api/v3")
+	return path == "/rate_limit" || path == "/user" || strings.HasPrefix(path, "/user/")
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || uncacheable(req.URL.Path) {
+		return t.next.RoundTrip(req)
+	}
+
+	path := t.path(req)
+	if entry := t.lookup(path); entry != nil {
+		t.count(true)
+		resp := entry.response(req)
+		resp.Header = resp.Header.Clone()
+		resp.Header.Set(cacheHitHeader, "hit")
+		return resp, nil
+	}
+	t.count(false)
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || (resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnprocessableEntity) {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf(tr("falha ao ler corpo da resposta: %w"), err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	class, ok := classify(resp.StatusCode, body)
+	if !ok || t.policy.ttl(class) <= 0 {
+		return resp, nil
+	}
+	entry := cacheEntry{
+		cassette: cassette{Method: req.Method, URL: req.URL.String(), StatusCode: resp.StatusCode, Header: resp.Header, Body: string(body)},
+		StoredAt: time.Now(),
+		Class:    class,
+	}
+	if data, err := json.Marshal(entry); err == nil {
+		// Falhar ao gravar o cache não deve derrubar a requisição.
+		_ = writeFileAtomic(path, data, 0o600)
+	}
+	return resp, nil
+}
+
+func (t *cachingTransport) path(req *http.Request) string {
+	return filepath.Join(t.dir, cacheKey(req, t.identity)+".json")
+}
+
+// lookup devolve a entrada em path se ela ainda estiver na validade.
+func (t *cachingTransport) lookup(path string) *cacheEntry {
+	entry, err := readCacheEntry(path)
+	if err != nil || time.Since(entry.StoredAt) >= t.policy.ttl(entry.Class) {
+		return nil
+	}
+	return entry
+}
+
+// fresh informa se req seria respondida pelo cache agora, sem contar um
+// acerto nem ir à rede.
+func (t *cachingTransport) fresh(req *http.Request) bool {
+	return req.Method == http.MethodGet && !uncacheable(req.URL.Path) && t.lookup(t.path(req)) != nil
+}
+
+// count registra um acerto ou erro de cache e persiste os contadores.
+func (t *cachingTransport) count(hit bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if hit {
+		t.stats.Hits++
+	} else {
+		t.stats.Misses++
+	}
+	if data, err := json.Marshal(t.stats); err == nil {
+		_ = writeFileAtomic(filepath.Join(t.dir, "stats.json"), data, 0o600)
+	}
+}
+
+// writeFileAtomic grava data em path por um arquivo temporário renomeado
+// no fim, para que um processo encerrado no meio da escrita (o fim do
+// prazo de -grace, um segundo Ctrl-C) nunca deixe no disco uma entrada
+// pela metade.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), perm)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+func readCacheEntry(path string) (*cacheEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// response reconstrói a resposta HTTP a partir da entrada de cache.
+func (c *cassette) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.StatusCode, http.StatusText(c.StatusCode)),
+		StatusCode:    c.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.Header,
+		Body:          io.NopCloser(strings.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+func readCacheStats(dir string) (cacheStats, error) {
+	var stats cacheStats
+	data, err := os.ReadFile(filepath.Join(dir, "stats.json"))
+	if err != nil {
+		return stats, err
+	}
+	err = json.Unmarshal(data, &stats)
+	return stats, err
+}
+
+// runCache implementa `cache stats` e `cache clear`.
+func runCache(args []string) error {
+	if len(args) != 1 {
+		return invalid(errors.New(tr("uso: cache stats | cache clear")))
+	}
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "stats":
+		stats, _ := readCacheStats(dir)
+		entries, size, err := cacheUsage(dir)
+		if err != nil {
+			return err
+		}
+		rate := 0.0
+		if total := stats.Hits + stats.Misses; total > 0 {
+			rate = 100 * float64(stats.Hits) / float64(total)
+		}
+		fmt.Printf(tr("Diretório:        %s\n"), dir)
+		fmt.Printf(tr("Entradas:         %d\n"), entries)
+		fmt.Printf(tr("Tamanho em disco: %.1f KB\n"), float64(size)/1024)
+		fmt.Printf(tr("Acertos/erros:    %d/%d (taxa de acerto %.1f%%)\n"), stats.Hits, stats.Misses, rate)
+		return nil
+	case "clear":
+		entries, _, err := cacheUsage(dir)
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf(tr("falha ao limpar cache: %w"), err)
+		}
+		fmt.Printf(tr("%d entradas removidas de %s\n"), entries, dir)
+		return nil
+	}
+	return invalid(errors.New(tr("uso: cache stats | cache clear")))
+}
+
+// cacheUsage conta as entradas (sem stats.json) e o espaço total ocupado.
+func cacheUsage(dir string) (entries int, size int64, err error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, 0, fmt.Errorf(tr("falha ao ler cache: %w"), err)
+	}
+	for _, f := range files {
+		// Ficam de fora as partições dos tenants do serve e temporários
+		// deixados por uma escrita interrompida.
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+		size += info.Size()
+		if f.Name() != "stats.json" {
+			entries++
+		}
+	}
+	return entries, size, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingServer responde {"login": <Authorization>} em qualquer caminho e
+// conta as requisições que chegaram até ele, por caminho.
+func countingServer(t *testing.T) (*httptest.Server, func(path string) int) {
+	t.Helper()
+	var mu sync.Mutex
+	hits := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"login": r.Header.Get("Authorization"), "total_count": 1})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func(path string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return hits[path]
+	}
+}
+
+// cachedClient cria um Client com cache num diretório temporário.
+func cachedClient(t *testing.T, base, token string) *Client {
+	t.Helper()
+	c, err := NewClient(WithBaseURL(base), WithToken(token), WithCache(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetProgress(quietProgress())
+	return c
+}
+
+func getLogin(t *testing.T, c *Client, path string) string {
+	t.Helper()
+	req, err := c.newRequest(context.Background(), http.MethodGet, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct{ Login string }
+	if _, err := c.do(req, &body); err != nil {
+		t.Fatal(err)
+	}
+	return body.Login
+}
+
+func TestCacheKeyedByIdentity(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	srv, hits := countingServer(t)
+
+	alice := cachedClient(t, srv.URL, "alice")
+	getLogin(t, alice, "/repos/a/b")
+	getLogin(t, alice, "/repos/a/b")
+	if n := hits("/repos/a/b"); n != 1 {
+		t.Fatalf("mesmo token: %d requisições, quer 1 (a segunda vem do cache)", n)
+	}
+
+	bob := cachedClient(t, srv.URL, "bob")
+	if got := getLogin(t, bob, "/repos/a/b"); got != "Bearer bob" {
+		t.Errorf("token trocado recebeu a resposta de %q", got)
+	}
+	if n := hits("/repos/a/b"); n != 2 {
+		t.Errorf("token trocado: %d requisições, quer 2 (não pode vir do cache)", n)
+	}
+}
+
+// Com pool, o rodízio de tokens não muda a chave, e a busca pública é a
+// mesma para qualquer credencial.
+func TestCacheSharedAcrossPool(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	srv, hits := countingServer(t)
+
+	c := cachedClient(t, srv.URL, "alice")
+	c.SetTokenPool(newTokenPool([]string{"alice", "bob", "carol"}, false))
+	for range 4 {
+		getLogin(t, c, "/repos/a/b")
+		getLogin(t, c, "/search/repositories")
+	}
+	for _, path := range []string{"/repos/a/b", "/search/repositories"} {
+		if n := hits(path); n != 1 {
+			t.Errorf("pool: %s foi %d vezes à rede, quer 1", path, n)
+		}
+	}
+
+	dave := cachedClient(t, srv.URL, "dave")
+	getLogin(t, dave, "/search/repositories")
+	if n := hits("/search/repositories"); n != 1 {
+		t.Errorf("busca com outro token: %d requisições, quer 1 (vem do cache)", n)
+	}
+}
+
+func TestCacheSkipsUserEndpoints(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	srv, hits := countingServer(t)
+
+	c := cachedClient(t, srv.URL, "alice")
+	for _, path := range []string{"/user", "/user/starred", "/api/v3/user", "/rate_limit"} {
+		getLogin(t, c, path)
+		getLogin(t, c, path)
+		if n := hits(path); n != 2 {
+			t.Errorf("%s: %d requisições, quer 2 (nunca do cache)", path, n)
+		}
+	}
+}
+
+// Buscas servidas do cache não gastam a vez do limitador: com uma busca
+// por minuto, as repetidas saem na hora.
+func TestCachedSearchesSkipLimiter(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	srv, hits := countingServer(t)
+	c, err := NewClient(WithBaseURL(srv.URL), WithCache(time.Hour), WithRateLimiter(newRateLimiter(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetProgress(quietProgress())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	opts := SearchOptions{Query: "language:go", PerPage: 10}
+	for i := range 5 {
+		if _, err := c.SearchRepositories(ctx, opts); err != nil {
+			t.Fatalf("busca %d: %v", i+1, err)
+		}
+	}
+	if n := hits("/search/repositories"); n != 1 {
+		t.Errorf("%d buscas foram à rede, quer 1", n)
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// historyPoint são as contagens de um repositório em uma coleta.
+type historyPoint struct {
+	TakenAt string `json:"taken_at"`
+	Stars   int    `json:"stars"`
+	Forks   int    `json:"forks"`
+}
+
+// Estilos de sparkline aceitos por -style.
+const (
+	styleBlocks  = "blocks"
+	styleBraille = "braille"
+	styleASCII   = "ascii"
+)
+
+// runChart implementa `chart owner/repo [-db snapshots.db]`.
+func runChart(args []string) error {
+	fs := newFlagSet("chart")
+	db := fs.String("db", "snapshots.db", "banco SQLite com os snapshots gravados pelo daemon")
+	width := fs.Int("width", 60, "largura máxima do gráfico, em caracteres")
+	style := fs.String("style", styleBlocks, "estilo do gráfico: blocks, braille ou ascii")
+
+	// O repositório pode vir antes ou depois das flags.
+	var repo string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		repo, args = args[0], args[1:]
+	}
+	fs.Parse(args)
+	if repo == "" && fs.NArg() > 0 {
+		repo = fs.Arg(0)
+	}
+	if !strings.Contains(repo, "/") {
+		return invalid(errors.New(tr("uso: chart owner/repo [-db snapshots.db]")))
+	}
+	if *width < 1 {
+		return invalid(errors.New(tr("-width deve ser positivo")))
+	}
+	switch *style {
+	case styleBlocks, styleBraille, styleASCII:
+	default:
+		return invalid(fmt.Errorf(tr("-style aceita blocks, braille ou ascii, recebido %q"), *style))
+	}
+
+	points, err := loadHistory(context.Background(), *db, repo)
+	if err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		return fmt.Errorf(tr("nenhum snapshot de %s em %s"), repo, *db)
+	}
+	return writeChart(os.Stdout, repo, points, *width, *style)
+}
+
+// loadHistory lê do banco a série de estrelas e forks de fullName, em
+// ordem cronológica. Se a mesma coleta tiver o repositório em mais de um
+// job, vale o maior valor.
+func loadHistory(ctx context.Context, path, fullName string) ([]historyPoint, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf(tr("falha ao abrir banco de snapshots: %w"), err)
+	}
+	sql := fmt.Sprintf(`SELECT taken_at, MAX(stars) AS stars, MAX(forks) AS forks FROM snapshots
+WHERE full_name = %s COLLATE NOCASE GROUP BY taken_at ORDER BY taken_at;`, sqlQuote(fullName))
+	var points []historyPoint
+	if err := sqliteQuery(ctx, path, sql, &points); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// writeChart imprime o cabeçalho, a sparkline e o resumo da série.
+func writeChart(w io.Writer, repo string, points []historyPoint, width int, style string) error {
+	values := make([]int, len(points))
+	for i, p := range points {
+		values[i] = p.Stars
+	}
+	first, last := points[0], points[len(points)-1]
+	lo, hi := minMax(values)
+
+	fmt.Fprintf(w, tr("%s — %d snapshots de %s a %s\n"), repo, len(points), chartDate(first.TakenAt), chartDate(last.TakenAt))
+	fmt.Fprintln(w, sparkline(values, width, style))
+	_, err := fmt.Fprintf(w, tr("mín %d  máx %d  variação %+d\n"), lo, hi, last.Stars-first.Stars)
+	return err
+}
+
+// chartDate encurta o taken_at RFC 3339 para a data.
+func chartDate(s string) string {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return s
+}
+
+// sparkline desenha values em no máximo width caracteres.
+func sparkline(values []int, width int, style string) string {
+	if style == styleBraille {
+		// Cada caractere braille carrega duas colunas de pontos.
+		return brailleLine(resample(values, 2*width))
+	}
+	levels := []rune("▁▂▃▄▅▆▇█")
+	if style == styleASCII {
+		levels = []rune("_.-=+*#@")
+	}
+	values = resample(values, width)
+	lo, hi := minMax(values)
+	var b strings.Builder
+	for _, v := range values {
+		b.WriteRune(levels[scale(v, lo, hi, len(levels))])
+	}
+	return b.String()
+}
+
+// brailleLine desenha cada valor como uma coluna de 1 a 4 pontos.
+func brailleLine(values []int) string {
+	// Bits dos pontos de cada coluna do caractere, de baixo para cima.
+	left := [4]rune{0x40, 0x04, 0x02, 0x01}
+	right := [4]rune{0x80, 0x20, 0x10, 0x08}
+
+	lo, hi := minMax(values)
+	var b strings.Builder
+	for i := 0; i < len(values); i += 2 {
+		r := rune(0x2800)
+		for row := 0; row <= scale(values[i], lo, hi, 4); row++ {
+			r |= left[row]
+		}
+		if i+1 < len(values) {
+			for row := 0; row <= scale(values[i+1], lo, hi, 4); row++ {
+				r |= right[row]
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// resample reduz values a no máximo n pontos, ficando com o último valor
+// de cada intervalo (estrelas são cumulativas, então o último é o mais
+// representativo).
+func resample(values []int, n int) []int {
+	if len(values) <= n {
+		return values
+	}
+	out := make([]int, n)
+	for i := range out {
+		out[i] = values[(i+1)*len(values)/n-1]
+	}
+	return out
+}
+
+// scale mapeia v, entre lo e hi, para um nível de 0 a levels-1. Séries
+// constantes ficam no nível do meio.
+func scale(v, lo, hi, levels int) int {
+	if hi == lo {
+		return levels / 2
+	}
+	return (v - lo) * (levels - 1) / (hi - lo)
+}
+
+func minMax(values []int) (lo, hi int) {
+	lo, hi = values[0], values[0]
+	for _, v := range values[1:] {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	return lo, hi
+}
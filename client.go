@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GitHubAPIURL é a raiz da API REST do GitHub.
+const GitHubAPIURL = "https://api.github.com"
+
+// Doer é o mínimo que o Client precisa de um cliente HTTP. *http.Client
+// o satisfaz; em testes ou produção pode-se injetar fakes, clientes
+// instrumentados ou transportes próprios.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client encapsula o acesso à API do GitHub: URL base, cliente HTTP
+// e o token (opcional) usado para autenticar as requisições.
+type Client struct {
+	httpClient Doer
+	baseURL    string
+	token      string
+	userAgent  string
+
+	// searchLimiter, quando presente, controla o ritmo das chamadas a
+	// /search/*, que têm um limite por minuto bem menor que o resto da API.
+	searchLimiter *rateLimiter
+
+	// cache é o cache em disco montado por buildHTTPClient, se houver;
+	// roundTrip o consulta para não segurar no limitador o que ele responde.
+	cache *cachingTransport
+
+	// pool, quando presente, escolhe o token de cada requisição.
+	pool *tokenPool
+
+	// maxBodySize limita o corpo (descomprimido) de cada resposta.
+	maxBodySize int64
+
+	// progress, quando presente, recebe o andamento de buscas longas.
+	progress *progress
+
+	// retries e retryBackoff controlam a repetição de falhas temporárias
+	// (veja WithRetry).
+	retries      int
+	retryBackoff time.Duration
+
+	// apiVersion vai no header X-GitHub-Api-Version (veja WithAPIVersion);
+	// deprecations evita repetir os avisos de obsolescência.
+	apiVersion   string
+	deprecations deprecationWarnings
+
+	// usage acumula as chamadas e a quota desta execução (veja runMeta).
+	usage clientUsage
+
+	// Prazos por classe de operação (veja timeout.go).
+	timeout       time.Duration
+	searchTimeout time.Duration
+	enrichTimeout time.Duration
+
+	// Usados só na montagem do cliente HTTP padrão (buildHTTPClient).
+	cachePolicy    CachePolicy
+	cachePartition string
+	middlewares    []Middleware
+}
+
+// NewClient cria um Client apontando para a API pública do GitHub,
+// configurado pelas opções (veja ClientOption). Sem opções, as
+// requisições são anônimas, com timeout de 10s por tentativa, respostas
+// limitadas a defaultMaxBodySize e sem cache nem repetição.
+func NewClient(opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		baseURL:      GitHubAPIURL,
+		userAgent:    defaultUserAgent(),
+		apiVersion:   defaultAPIVersion,
+		maxBodySize:  defaultMaxBodySize,
+		timeout:      defaultTimeout,
+		retryBackoff: defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.buildHTTPClient(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SearchResult mapeia os campos principais da resposta da API do GitHub
+type SearchResult struct {
+	TotalCount int          `json:"total_count"`
+	Items      []Repository `json:"items"` // Um slice de repositórios
+
+	// Partial não é nil quando a busca parou no meio (veja PartialError).
+	Partial *PartialError `json:"-"`
+
+	// Meta, quando presente, vai como objeto meta na saída JSON.
+	Meta *runMeta `json:"-"`
+}
+
+// Repository mapeia os campos de um item de repositório individual
+// Estamos interessados apenas em alguns campos (as "features").
+type Repository struct {
+	Name          string    `json:"name"`
+	FullName      string    `json:"full_name"`
+	Owner         Owner     `json:"owner"`
+	URL           string    `json:"html_url"`
+	Description   string    `json:"description"`
+	Language      string    `json:"language"`
+	Stars         int       `json:"stargazers_count"` // A "feature" que usaremos para ordenar
+	Forks         int       `json:"forks_count"`
+	Watchers      int       `json:"watchers_count"`
+	OpenIssues    int       `json:"open_issues_count"`
+	Size          int       `json:"size"` // Em KB
+	DefaultBranch string    `json:"default_branch"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	PushedAt      time.Time `json:"pushed_at"`
+	License       *License  `json:"license"` // nil quando o GitHub não detecta licença
+	Archived      bool      `json:"archived"`
+	Topics        []string  `json:"topics"`
+	Score         float64   `json:"score"` // relevância da busca; só significa algo com -sort best-match
+
+	// Campos preenchidos pelos enriquecimentos (-enrich), não pela busca.
+	CIStatus         string         `json:"ci_status,omitempty"`
+	Advisories       *int           `json:"advisories,omitempty"`        // security advisories publicados
+	DependabotAlerts *int           `json:"dependabot_alerts,omitempty"` // nil se o token não tiver acesso
+	LastCommitAt     *time.Time     `json:"last_commit_at,omitempty"`    // último commit na branch padrão
+	CommitActivity   []int          `json:"commit_activity,omitempty"`   // commits por semana no último ano
+	Languages        map[string]int `json:"languages,omitempty"`         // bytes de código por linguagem
+	LatestRelease    *Release       `json:"latest_release,omitempty"`    // nil se não houver releases
+
+	// StarredAt é quando o usuário autenticado deu estrela (subcomando starred).
+	StarredAt *time.Time `json:"starred_at,omitempty"`
+
+	// Notes são as anotações pessoais (subcomando note) sobre o repositório.
+	Notes []Note `json:"notes,omitempty"`
+
+	// Unavailable lista os enriquecimentos que falharam, com o motivo.
+	Unavailable map[string]string `json:"unavailable,omitempty"`
+}
+
+// License mapeia a licença detectada pelo GitHub.
+type License struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	SPDXID string `json:"spdx_id"`
+}
+
+// Owner mapeia o dono (usuário ou organização) de um repositório.
+type Owner struct {
+	Login     string `json:"login"`
+	Type      string `json:"type"` // "User" ou "Organization"
+	AvatarURL string `json:"avatar_url"`
+}
+
+// newRequest monta uma requisição para um caminho da API (ex: "/user"),
+// já com os headers obrigatórios e a autenticação, quando houver token.
+func (c *Client) newRequest(ctx context.Context, method, path string, params url.Values) (*http.Request, error) {
+	fullURL := c.baseURL + path
+	if len(params) > 0 {
+		fullURL = fmt.Sprintf("%s?%s", fullURL, params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf(tr("falha ao criar requisição: %w"), err)
+	}
+
+	// Headers OBRIGATÓRIOS da API do GitHub
+	// Sem eles, a API retornará 403 Forbidden.
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", c.userAgent) // A API exige um User-Agent
+	req.Header.Set("Accept-Encoding", "gzip") // descomprimido em roundTrip
+	if c.apiVersion != "" {
+		req.Header.Set("X-GitHub-Api-Version", c.apiVersion)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
+
+// APIError representa uma resposta não-OK da API, com a mensagem que o
+// GitHub devolve no corpo (quando houver).
+type APIError struct {
+	StatusCode int
+	Status     string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf(tr("API do GitHub retornou status não-OK: %s (%s)"), e.Status, e.Message)
+	}
+	return fmt.Sprintf(tr("API do GitHub retornou status não-OK: %s"), e.Status)
+}
+
+// isStatus informa se err é um APIError com o status HTTP indicado.
+func isStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// roundTrip executa a requisição e devolve a resposta com o corpo ainda
+// por ler, já descomprimido e limitado a maxBodySize. Status fora da faixa
+// 2xx viram *APIError (com o corpo já consumido e fechado).
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	// A vez no limitador só é gasta pelo que vai à rede: uma busca que o
+	// cache responde sai na hora.
+	limiter := c.searchLimiter
+	if !strings.Contains(req.URL.Path, "/search/") || c.cache != nil && c.cache.fresh(req) {
+		limiter = nil
+	}
+	if limiter != nil {
+		if at := limiter.ready(); time.Until(at) > time.Second {
+			c.progress.Waiting(at)
+		}
+		if err := limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	var cred *credential
+	if c.pool != nil {
+		cred = c.pool.authorize(req)
+	}
+
+	resp, err := c.doWithRetry(req)
+	if err != nil {
+		return nil, fmt.Errorf(tr("falha ao executar requisição: %w"), err)
+	}
+	c.checkDeprecation(req, resp)
+	rate := parseRate(resp.Header)
+	c.usage.observe(req, resp, rate)
+	if resp.Header.Get(cacheHitHeader) == "" {
+		// A quota de uma resposta do cache é a de quando foi guardada.
+		c.progress.Rate(rate)
+		if cred != nil {
+			// Com pool, o limitador só segura a busca quando todos os tokens
+			// esgotaram a quota.
+			rate = c.pool.observe(cred, req.URL.Path, rate, time.Now())
+		}
+		if limiter != nil {
+			limiter.Observe(rate)
+		}
+	}
+
+	body, err := decodeBody(resp, c.maxBodySize)
+	if err != nil {
+		resp.Body.Close()
+		return resp, err
+	}
+	resp.Body = body
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		apiErr := &APIError{StatusCode: resp.StatusCode, Status: resp.Status}
+		var payload struct {
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&payload) == nil {
+			apiErr.Message = payload.Message
+		}
+		return resp, apiErr
+	}
+	return resp, nil
+}
+
+// send executa a requisição e devolve o corpo já lido, para respostas que
+// não são JSON (ex: README bruto).
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return resp, nil, err
+	}
+	defer resp.Body.Close() // Boa prática: sempre fechar o corpo da resposta
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, nil, fmt.Errorf(tr("falha ao ler corpo da resposta: %w"), err)
+	}
+	return resp, body, nil
+}
+
+// do executa a requisição e decodifica o corpo JSON em v (se não for nil)
+// direto do stream, sem guardar o corpo inteiro em memória. A resposta é
+// devolvida para que o chamador possa inspecionar os headers.
+func (c *Client) do(req *http.Request, v any) (*http.Response, error) {
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	if v == nil {
+		// Consumir o corpo permite reaproveitar a conexão.
+		if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+			return resp, fmt.Errorf(tr("falha ao ler corpo da resposta: %w"), err)
+		}
+		return resp, nil
+	}
+	// Corpo vazio (io.EOF) deixa v como está.
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil && err != io.EOF {
+		return resp, fmt.Errorf(tr("falha ao decodificar JSON: %w"), err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// defaultClusterThreshold é a similaridade mínima (Jaccard) para dois
+// repositórios caírem no mesmo cluster.
+const defaultClusterThreshold = 0.25
+
+// repoCluster agrupa repositórios com descrições e tópicos parecidos. Label são as
+// palavras mais frequentes do grupo.
+type repoCluster struct {
+	Label []string
+	Repos []Repository
+}
+
+// stopwords são palavras frequentes demais para indicar similaridade.
+var stopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "that": true,
+	"this": true, "your": true, "you": true, "are": true, "into": true, "based": true,
+	"using": true, "written": true, "simple": true, "fast": true, "library": true,
+	"tool": true, "project": true, "uma": true, "para": true, "com": true, "dos": true,
+	"das": true, "por": true, "que": true,
+}
+
+// repoTokens devolve o conjunto de palavras do nome, da descrição e dos
+// tópicos.
+func repoTokens(r Repository) map[string]bool {
+	tokens := map[string]bool{}
+	text := r.Name + " " + r.Description + " " + strings.Join(r.Topics, " ")
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	}) {
+		if len(w) >= 3 && !stopwords[w] {
+			tokens[w] = true
+		}
+	}
+	return tokens
+}
+
+// jaccard é |a ∩ b| / |a ∪ b|; conjuntos vazios não se parecem com nada.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for t := range a {
+		if b[t] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
+
+// clusterRepos agrupa por ligação simples: dois repositórios ficam juntos
+// se houver uma cadeia de pares com similaridade >= threshold. Os clusters
+// saem do maior para o menor, mantendo a ordem original dentro de cada um.
+func clusterRepos(repos []Repository, threshold float64) []repoCluster {
+	tokens := make([]map[string]bool, len(repos))
+	for i, r := range repos {
+		tokens[i] = repoTokens(r)
+	}
+
+	parent := make([]int, len(repos))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range repos {
+		for j := i + 1; j < len(repos); j++ {
+			if jaccard(tokens[i], tokens[j]) >= threshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	index := map[int]int{}
+	var clusters []repoCluster
+	var members [][]int
+	for i, r := range repos {
+		root := find(i)
+		c, ok := index[root]
+		if !ok {
+			c = len(clusters)
+			index[root] = c
+			clusters = append(clusters, repoCluster{})
+			members = append(members, nil)
+		}
+		clusters[c].Repos = append(clusters[c].Repos, r)
+		members[c] = append(members[c], i)
+	}
+	for c := range clusters {
+		clusters[c].Label = clusterLabel(tokens, members[c], 3)
+	}
+
+	sort.SliceStable(clusters, func(a, b int) bool {
+		return len(clusters[a].Repos) > len(clusters[b].Repos)
+	})
+	return clusters
+}
+
+// clusterLabel escolhe as n palavras mais comuns entre os membros. Em
+// clusters de um só repositório, o rótulo é vazio.
+func clusterLabel(tokens []map[string]bool, members []int, n int) []string {
+	if len(members) < 2 {
+		return nil
+	}
+	counts := map[string]int{}
+	for _, m := range members {
+		for t := range tokens[m] {
+			counts[t]++
+		}
+	}
+	var words []string
+	for t, c := range counts {
+		if c > 1 {
+			words = append(words, t)
+		}
+	}
+	sort.Slice(words, func(a, b int) bool {
+		if counts[words[a]] != counts[words[b]] {
+			return counts[words[a]] > counts[words[b]]
+		}
+		return words[a] < words[b]
+	})
+	return words[:min(n, len(words))]
+}
+
+// jsonCluster é o formato JSON de um cluster.
+type jsonCluster struct {
+	Label []string `json:"label,omitempty"`
+	Size  int      `json:"size"`
+	Items []row    `json:"items"`
+}
+
+// writeClusters escreve os clusters: text e table imprimem uma tabela por
+// cluster, csv ganha a coluna cluster e json lista os clusters.
+func writeClusters(w io.Writer, format string, clusters []repoCluster, fields []field) error {
+	switch format {
+	case formatText, formatTable:
+		for i, c := range clusters {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			title := tr("(sem similares)")
+			if len(c.Repos) > 1 {
+				title = strings.Join(c.Label, ", ")
+			}
+			fmt.Fprintf(w, tr("## Cluster %d — %d repositórios: %s\n"), i+1, len(c.Repos), title)
+			if err := writeTable(w, c.Repos, fields); err != nil {
+				return err
+			}
+		}
+		return nil
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		headers := []string{"cluster"}
+		for _, f := range fields {
+			headers = append(headers, f.Name)
+		}
+		cw.Write(headers)
+		for i, c := range clusters {
+			for _, repo := range c.Repos {
+				cells := []string{strconv.Itoa(i + 1)}
+				for _, f := range fields {
+					cells = append(cells, formatValue(f.Value(repo)))
+				}
+				cw.Write(cells)
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case formatJSON:
+		out := make([]jsonCluster, len(clusters))
+		for i, c := range clusters {
+			rows := make([]row, len(c.Repos))
+			for j, repo := range c.Repos {
+				rows[j] = row{fields: fields, repo: repo}
+			}
+			out[i] = jsonCluster{Label: c.Label, Size: len(c.Repos), Items: rows}
+		}
+		return encodeJSON(w, out)
+	}
+	return fmt.Errorf(tr("formato desconhecido: %q (use text, table, csv ou json)"), format)
+}
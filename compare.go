@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// languageSummary resume os resultados de uma linguagem na comparação.
+type languageSummary struct {
+	Language    string       `json:"language"`
+	TotalCount  int          `json:"total_count"`
+	Sampled     int          `json:"sampled"`
+	MedianStars float64      `json:"median_stars"`
+	Top         []Repository `json:"top"`
+}
+
+// runCompareLanguages implementa `compare-languages go,rust,zig -q "..."`.
+func runCompareLanguages(args []string) error {
+	fs := newFlagSet("compare-languages")
+	query := fs.String("q", "", "qualificadores comuns a todas as linguagens (ex: topic:web-framework)")
+	sample := fs.Int("sample", 100, "repositórios buscados por linguagem para calcular a mediana")
+	top := fs.Int("top", 5, "quantos repositórios mostrar por linguagem")
+	format := fs.String("format", formatTable, "formato de saída: table ou json")
+	cf := addClientFlags(fs)
+
+	// A lista de linguagens pode vir antes ou depois das flags.
+	var langSpec string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		langSpec, args = args[0], args[1:]
+	}
+	fs.Parse(args)
+	if langSpec == "" && fs.NArg() > 0 {
+		langSpec = fs.Arg(0)
+	}
+
+	var languages []string
+	for _, l := range strings.Split(langSpec, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			languages = append(languages, l)
+		}
+	}
+	if len(languages) == 0 {
+		return invalid(errors.New(tr("uso: compare-languages go,rust,zig [-q \"topic:web-framework\"]")))
+	}
+	if *query != "" {
+		if err := validateQuery(*query); err != nil {
+			return err
+		}
+	}
+	if *sample < 1 || *sample > maxSearchResults {
+		return invalid(fmt.Errorf(tr("-sample deve estar entre 1 e %d"), maxSearchResults))
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := cf.context()
+	defer cancel()
+	summaries, err := compareLanguages(ctx, client, *query, languages, *sample, *top)
+	if err != nil {
+		return err
+	}
+	return writeComparison(os.Stdout, *format, summaries)
+}
+
+// compareLanguages roda a mesma busca para cada linguagem em paralelo.
+func compareLanguages(ctx context.Context, client *Client, query string, languages []string, sample, top int) ([]languageSummary, error) {
+	summaries := make([]languageSummary, len(languages))
+	errs := make([]error, len(languages))
+
+	var wg sync.WaitGroup
+	for i, lang := range languages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			opts := SearchOptions{
+				Query:   strings.TrimSpace(query + " language:" + lang),
+				Sort:    "stars",
+				Order:   "desc",
+				PerPage: min(sample, maxPerPage),
+			}
+			result, err := client.SearchAllRepositories(ctx, opts, sample)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", lang, err)
+				return
+			}
+			summaries[i] = summarizeLanguage(lang, result, top)
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func summarizeLanguage(lang string, result *SearchResult, top int) languageSummary {
+	s := languageSummary{Language: lang, TotalCount: result.TotalCount, Sampled: len(result.Items)}
+
+	stars := make([]int, len(result.Items))
+	for i, r := range result.Items {
+		stars[i] = r.Stars
+	}
+	s.MedianStars = median(stars)
+
+	s.Top = result.Items[:min(top, len(result.Items))]
+	return s
+}
+
+// median calcula a mediana; com quantidade par, a média dos dois centrais.
+func median(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return float64(sorted[mid-1]+sorted[mid]) / 2
+	}
+	return float64(sorted[mid])
+}
+
+func writeComparison(w io.Writer, format string, summaries []languageSummary) error {
+	switch format {
+	case formatTable, formatText:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, tr("LINGUAGEM\tTOTAL\tMEDIANA DE ESTRELAS\tPRINCIPAIS"))
+		for _, s := range summaries {
+			names := make([]string, len(s.Top))
+			for i, r := range s.Top {
+				names[i] = fmt.Sprintf("%s (%d)", r.FullName, r.Stars)
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%.1f\t%s\n", s.Language, s.TotalCount, s.MedianStars, strings.Join(names, ", "))
+		}
+		return tw.Flush()
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(summaries); err != nil {
+			return fmt.Errorf(tr("falha ao codificar JSON: %w"), err)
+		}
+		return nil
+	}
+	return fmt.Errorf(tr("formato desconhecido: %q (use table ou json)"), format)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule é uma expressão cron padrão de 5 campos
+// (minuto hora dia-do-mês mês dia-da-semana) já interpretada.
+// Também aceita os atalhos @hourly, @daily, @weekly, @monthly, @yearly
+// e @every <duração> (ex: @every 15m).
+type cronSchedule struct {
+	minute, hour, dom, month, dow [64]bool
+	domAny, dowAny                bool
+	every                         time.Duration // usado apenas por @every
+}
+
+var cronAliases = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseCron interpreta uma expressão cron.
+func parseCron(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if rest, ok := strings.CutPrefix(expr, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || d < time.Second {
+			return nil, fmt.Errorf(tr("cron: intervalo inválido em %q"), expr)
+		}
+		return &cronSchedule{every: d}, nil
+	}
+	if alias, ok := cronAliases[expr]; ok {
+		expr = alias
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf(tr("cron: esperados 5 campos em %q, encontrados %d"), expr, len(parts))
+	}
+
+	s := &cronSchedule{domAny: parts[2] == "*", dowAny: parts[4] == "*"}
+	fields := []struct {
+		set      *[64]bool
+		min, max int
+		name     string
+	}{
+		{&s.minute, 0, 59, tr("minuto")},
+		{&s.hour, 0, 23, tr("hora")},
+		{&s.dom, 1, 31, tr("dia do mês")},
+		{&s.month, 1, 12, tr("mês")},
+		{&s.dow, 0, 7, tr("dia da semana")},
+	}
+	for i, f := range fields {
+		if err := parseCronField(parts[i], f.min, f.max, f.set); err != nil {
+			return nil, fmt.Errorf(tr("cron: campo %s: %w"), f.name, err)
+		}
+	}
+	// Domingo pode ser 0 ou 7.
+	if s.dow[7] {
+		s.dow[0] = true
+	}
+	return s, nil
+}
+
+// parseCronField aceita *, valores, intervalos (1-5), listas (1,3,5) e
+// passos (*/15, 0-30/10).
+func parseCronField(field string, min, max int, set *[64]bool) error {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return fmt.Errorf(tr("passo inválido %q"), stepPart)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			loStr, hiStr, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = strconv.Atoi(loStr); err != nil {
+				return fmt.Errorf(tr("valor inválido %q"), loStr)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiStr); err != nil {
+					return fmt.Errorf(tr("valor inválido %q"), hiStr)
+				}
+			} else if hasStep {
+				hi = max // "5/10" equivale a "5-max/10"
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return fmt.Errorf(tr("%q fora do intervalo %d-%d"), part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return nil
+}
+
+// Next devolve o primeiro instante estritamente depois de t que casa com
+// a expressão. Como no cron tradicional, se dia do mês e dia da semana
+// forem restritos, basta um deles casar.
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Add(s.every)
+	}
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// Cinco anos cobrem qualquer expressão válida (ex: 29 de fevereiro).
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if !s.month[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hour[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minute[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom, dow := s.dom[t.Day()], s.dow[int(t.Weekday())]
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	}
+	return dom || dow
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// daemonConfig é o arquivo de configuração do modo daemon, ex:
+//
+//	{
+//	  "jobs": [{
+//	    "name": "go-top",
+//	    "schedule": "0 * * * *",
+//	    "query": "language:go",
+//	    "limit": 100,
+//	    "sinks": [
+//	      {"type": "file", "path": "go-top.jsonl"},
+//	      {"type": "sqlite", "path": "snapshots.db"},
+//	      {"type": "webhook", "url": "https://example.com/hook"},
+//	      {"type": "s3", "url": "s3://bucket/snapshots"}
+//	    ]
+//	  }]
+//	}
+type daemonConfig struct {
+	Jobs []daemonJob `json:"jobs"`
+}
+
+// daemonJob é uma busca agendada e os destinos dos seus resultados.
+type daemonJob struct {
+	Name     string       `json:"name"`
+	Schedule string       `json:"schedule"` // expressão cron
+	Query    string       `json:"query"`
+	Sort     string       `json:"sort"`
+	Order    string       `json:"order"`
+	Limit    int          `json:"limit"`
+	Sinks    []sinkConfig `json:"sinks"`
+
+	schedule *cronSchedule
+	sinks    sinkList
+	strict   bool // -strict: busca parcial não vai para os sinks
+}
+
+// sinkConfig descreve um destino no arquivo de configuração; os tipos são
+// os mesmos de -sink (veja parseSink): stdout, file e sqlite (path),
+// webhook e s3 (url).
+type sinkConfig struct {
+	Type string `json:"type"`
+	Path string `json:"path,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// open cria o Sink correspondente à configuração.
+func (c sinkConfig) open() (Sink, error) {
+	switch c.Type {
+	case "stdout":
+		return parseSink("stdout")
+	case "file", "sqlite":
+		return parseSink(c.Type + ":" + c.Path)
+	case "webhook":
+		return parseSink("webhook:" + c.URL)
+	case "s3":
+		return parseSink(c.URL)
+	}
+	return nil, fmt.Errorf(tr("tipo de sink desconhecido %q"), c.Type)
+}
+
+// loadDaemonConfig lê e valida a configuração, preenchendo os padrões.
+func loadDaemonConfig(path string) (*daemonConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(tr("falha ao ler configuração do daemon: %w"), err)
+	}
+	var cfg daemonConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf(tr("configuração do daemon inválida: %w"), err)
+	}
+	if len(cfg.Jobs) == 0 {
+		return nil, errors.New(tr("configuração do daemon sem jobs"))
+	}
+
+	for i := range cfg.Jobs {
+		job := &cfg.Jobs[i]
+		if job.Name == "" {
+			job.Name = fmt.Sprintf("job-%d", i+1)
+		}
+		if err := validateQuery(job.Query); err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		if job.schedule, err = parseCron(job.Schedule); err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		if job.Sort == "" {
+			job.Sort = "stars"
+		}
+		if job.Order == "" {
+			job.Order = "desc"
+		}
+		if job.Limit <= 0 {
+			job.Limit = 30
+		}
+		if len(job.Sinks) == 0 {
+			return nil, fmt.Errorf(tr("job %s: nenhum sink configurado"), job.Name)
+		}
+		for _, sc := range job.Sinks {
+			sink, err := sc.open()
+			if err != nil {
+				return nil, fmt.Errorf("job %s: %w", job.Name, err)
+			}
+			job.sinks = append(job.sinks, sink)
+		}
+	}
+	return &cfg, nil
+}
+
+// runDaemon implementa o subcomando `daemon`: executa os jobs agendados
+// até receber SIGINT/SIGTERM. Aí nenhum job novo começa, e os que estão
+// rodando têm até -grace para terminar a busca e a escrita nos sinks.
+func runDaemon(args []string) error {
+	fs := newFlagSet("daemon")
+	configPath := fs.String("config", "", "arquivo de configuração (padrão: daemon.json no diretório de configuração)")
+	once := fs.Bool("once", false, "executa cada job uma vez, imediatamente, e sai")
+	grace := fs.Duration("grace", defaultGracePeriod, "prazo para os jobs em andamento terminarem depois de SIGINT/SIGTERM")
+	strict := fs.Bool("strict", false, "não grava o snapshot se alguma página da busca falhar, em vez de gravar os resultados parciais")
+	cf := addClientFlags(fs)
+	fs.Parse(args)
+
+	if *grace < 0 {
+		return invalid(errors.New(tr("-grace não pode ser negativo")))
+	}
+	if *configPath == "" {
+		dir, err := configDir()
+		if err != nil {
+			return err
+		}
+		*configPath = filepath.Join(dir, "daemon.json")
+	}
+	cfg, err := loadDaemonConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	for i := range cfg.Jobs {
+		cfg.Jobs[i].strict = *strict
+	}
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	stop, work, cancel := shutdownContexts(*grace)
+	defer cancel()
+
+	if *once {
+		for _, job := range cfg.Jobs {
+			if stop.Err() != nil {
+				break
+			}
+			if _, err := runDaemonJob(work, client, job, cf.runTimeout); err != nil {
+				return fmt.Errorf("job %s: %w", job.Name, err)
+			}
+		}
+		return nil
+	}
+
+	log.Printf(tr("daemon: %d jobs carregados de %s"), len(cfg.Jobs), *configPath)
+	var wg sync.WaitGroup
+	for _, job := range cfg.Jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scheduleJob(stop, work, client, job, cf.runTimeout)
+		}()
+	}
+	wg.Wait()
+	if work.Err() != nil {
+		log.Print(tr("daemon: prazo de encerramento esgotado; jobs em andamento interrompidos"))
+	} else {
+		log.Print(tr("daemon: encerrado"))
+	}
+	return nil
+}
+
+// scheduleJob espera cada horário do cron e executa o job. Falhas são
+// registradas no log e não interrompem o agendamento. A cada execução, o
+// log resume o que mudou desde a anterior. Cada execução tem até timeout
+// (0 = sem prazo) e roda em work; o agendamento para quando stop é
+// cancelado, sem interromper a execução em andamento.
+func scheduleJob(stop, work context.Context, client *Client, job daemonJob, timeout time.Duration) {
+	var prev *snapshot
+	for {
+		next := job.schedule.Next(time.Now())
+		if next.IsZero() {
+			log.Printf(tr("daemon: job %s nunca mais será executado"), job.Name)
+			return
+		}
+		log.Printf(tr("daemon: próxima execução de %s às %s"), job.Name, next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-stop.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		snap, err := runDaemonJob(work, client, job, timeout)
+		if err != nil {
+			log.Printf(tr("daemon: job %s falhou: %v"), job.Name, err)
+		}
+		// Um snapshot parcial não serve de base: a próxima comparação
+		// veria como removidos os repositórios que faltaram nele.
+		var partial *PartialError
+		if snap != nil && !errors.As(err, &partial) {
+			if prev != nil {
+				log.Printf(tr("daemon: job %s desde a última execução: %s"), job.Name, diffSnapshots(*prev, *snap).Summary())
+			}
+			prev = snap
+		}
+	}
+}
+
+// runDaemonJob executa a busca do job e entrega o snapshot a cada sink.
+// O snapshot é devolvido mesmo se algum sink falhar. Se a busca parar no
+// meio, o que já foi coletado vai para os sinks e o *PartialError volta
+// junto; com job.strict, nada é gravado. timeout limita a execução
+// inteira, busca e sinks (0 = sem prazo).
+func runDaemonJob(ctx context.Context, client *Client, job daemonJob, timeout time.Duration) (*snapshot, error) {
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+	opts := SearchOptions{Query: job.Query, Sort: job.Sort, Order: job.Order, PerPage: min(job.Limit, maxPerPage)}
+	result, err := client.SearchAllRepositories(ctx, opts, job.Limit)
+	if err != nil && (job.strict || result == nil) {
+		return nil, err
+	}
+	snap := newSnapshot(job.Query, result)
+	snap.Job = job.Name
+
+	log.Printf(tr("daemon: job %s coletou %d repositórios"), job.Name, len(result.Items))
+	return &snap, errors.Join(err, job.sinks.writeAll(ctx, snap))
+}
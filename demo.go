@@ -0,0 +1,218 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// demoFiles são as respostas de exemplo do modo -demo: demo/search.json
+// tem os repositórios que a busca filtra e ordena, e demo/responses.json as
+// demais respostas, por caminho (/repos/{dono}/{repo}/languages...).
+//
+//go:embed demo/*.json
+var demoFiles embed.FS
+
+// demoTransport responde às requisições com as respostas de exemplo
+// embutidas no binário, sem rede e sem token. A busca de repositórios
+// aplica à amostra os qualificadores mais comuns (language, topic, user,
+// org, archived e palavras soltas), a ordenação e a paginação, para que a
+// saída pareça a de uma busca de verdade; o resto responde pelo caminho
+// exato, ou 404.
+type demoTransport struct {
+	repos     []Repository
+	responses map[string]json.RawMessage
+}
+
+func newDemoTransport() (*demoTransport, error) {
+	t := &demoTransport{}
+	var search struct {
+		Items []Repository `json:"items"`
+	}
+	for _, f := range []struct {
+		name string
+		dst  any
+	}{
+		{"demo/search.json", &search},
+		{"demo/responses.json", &t.responses},
+	} {
+		data, err := demoFiles.ReadFile(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, f.dst); err != nil {
+			return nil, fmt.Errorf(tr("dados de exemplo corrompidos em %s: %w"), f.name, err)
+		}
+	}
+	t.repos = search.Items
+	return t, nil
+}
+
+func (t *demoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := req.URL.Path
+	switch {
+	case path == "/search/repositories":
+		return t.search(req)
+	case strings.HasPrefix(path, "/user/starred/") && (req.Method == http.MethodPut || req.Method == http.MethodDelete):
+		return demoResponse(req, http.StatusNoContent, nil, ""), nil
+	case req.Method != http.MethodGet:
+		return demoNotFound(req), nil
+	}
+
+	body, ok := t.responses[path]
+	if !ok {
+		return demoNotFound(req), nil
+	}
+	// O README e outros arquivos brutos ficam guardados como string JSON.
+	var raw string
+	if json.Unmarshal(body, &raw) == nil {
+		return demoResponse(req, http.StatusOK, nil, raw), nil
+	}
+	return demoResponse(req, http.StatusOK, nil, string(body)), nil
+}
+
+// search responde /search/repositories a partir da amostra.
+func (t *demoTransport) search(req *http.Request) (*http.Response, error) {
+	params := req.URL.Query()
+	terms, err := splitQuery(params.Get("q"))
+	if err != nil {
+		return demoResponse(req, http.StatusUnprocessableEntity, nil, `{"message":"Validation Failed"}`), nil
+	}
+
+	var found []Repository
+	for _, r := range t.repos {
+		if demoMatch(r, terms) {
+			found = append(found, r)
+		}
+	}
+	demoSort(found, params.Get("sort"), params.Get("order"))
+
+	perPage, _ := strconv.Atoi(params.Get("per_page"))
+	if perPage < 1 {
+		perPage = 30
+	}
+	page, _ := strconv.Atoi(params.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	var items []Repository
+	if from := (page - 1) * perPage; from < len(found) {
+		items = found[from:min(from+perPage, len(found))]
+	}
+
+	header := http.Header{}
+	if last := (len(found) + perPage - 1) / perPage; page < last {
+		header.Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, demoPageURL(req.URL, page+1), demoPageURL(req.URL, last)))
+	}
+	body, err := json.Marshal(map[string]any{"total_count": len(found), "incomplete_results": false, "items": items})
+	if err != nil {
+		return nil, err
+	}
+	return demoResponse(req, http.StatusOK, header, string(body)), nil
+}
+
+// demoMatch informa se r atende a todos os termos da query.
+func demoMatch(r Repository, terms []string) bool {
+	for _, term := range terms {
+		name, value, _ := strings.Cut(term, ":")
+		value = strings.ToLower(strings.Trim(value, `"`))
+		switch strings.ToLower(name) {
+		case "language":
+			if !strings.EqualFold(r.Language, value) {
+				return false
+			}
+		case "topic":
+			if !containsFold(r.Topics, value) {
+				return false
+			}
+		case "user", "org":
+			if !strings.EqualFold(r.Owner.Login, value) {
+				return false
+			}
+		case "archived":
+			if strconv.FormatBool(r.Archived) != value {
+				return false
+			}
+		default:
+			if qualifierName(term) != "" {
+				continue // os demais qualificadores não filtram a amostra
+			}
+			text := strings.ToLower(r.FullName + " " + r.Description + " " + strings.Join(r.Topics, " "))
+			if !strings.Contains(text, strings.ToLower(strings.Trim(term, `"`))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// demoSort ordena como a API: stars, forks ou updated; sem sort, por
+// relevância (score).
+func demoSort(repos []Repository, sortBy, order string) {
+	key := func(r Repository) float64 {
+		switch sortBy {
+		case "stars":
+			return float64(r.Stars)
+		case "forks":
+			return float64(r.Forks)
+		case "updated":
+			return float64(r.UpdatedAt.Unix())
+		}
+		return r.Score
+	}
+	asc := sortBy != "" && order == "asc"
+	sort.SliceStable(repos, func(a, b int) bool {
+		if asc {
+			return key(repos[a]) < key(repos[b])
+		}
+		return key(repos[a]) > key(repos[b])
+	})
+}
+
+func demoPageURL(u *url.URL, page int) string {
+	next := *u
+	q := next.Query()
+	q.Set("page", strconv.Itoa(page))
+	next.RawQuery = q.Encode()
+	return next.String()
+}
+
+func demoNotFound(req *http.Request) *http.Response {
+	return demoResponse(req, http.StatusNotFound, nil, `{"message":"Not Found"}`)
+}
+
+// demoResponse monta a resposta com os headers de quota que a API manda,
+// para que o rodapé e o indicador de progresso tenham o que mostrar.
+func demoResponse(req *http.Request, status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	if body != "" && header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	limit, remaining, resource := "5000", "4873", "core"
+	if strings.HasPrefix(req.URL.Path, "/search/") {
+		limit, remaining, resource = "30", "28", "search"
+	}
+	header.Set("X-RateLimit-Limit", limit)
+	header.Set("X-RateLimit-Remaining", remaining)
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	header.Set("X-RateLimit-Resource", resource)
+	c := cassette{Method: req.Method, URL: req.URL.String(), StatusCode: status, Header: header, Body: body}
+	return c.response(req)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// CodeResult mapeia um item de /search/code.
+type CodeResult struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	URL        string `json:"html_url"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		Fork     bool   `json:"fork"`
+	} `json:"repository"`
+}
+
+// CodeIterator cria um iterador sobre /search/code. A busca de código
+// exige autenticação e não aceita sort por estrelas.
+func (c *Client) CodeIterator(opts SearchOptions) *SearchIterator[CodeResult] {
+	return newSearchIterator[CodeResult](c, "/search/code", opts)
+}
+
+// dependentsQuery é a heurística de dependentes: go.mod que citam o módulo.
+func dependentsQuery(module string) string {
+	return fmt.Sprintf("%q filename:go.mod", module)
+}
+
+// moduleRepo deduz o repositório do próprio módulo (github.com/owner/repo/...),
+// para não listá-lo como dependente de si mesmo.
+func moduleRepo(module string) string {
+	parts := strings.Split(module, "/")
+	if len(parts) < 3 || parts[0] != "github.com" {
+		return ""
+	}
+	return parts[1] + "/" + parts[2]
+}
+
+// dependentRepos reduz os go.mod encontrados aos repositórios distintos,
+// na ordem em que apareceram, sem o repositório do módulo e, se
+// includeForks for falso, sem forks.
+func dependentRepos(results []CodeResult, module string, includeForks bool) []string {
+	self := moduleRepo(module)
+	seen := map[string]bool{}
+	var names []string
+	for _, r := range results {
+		name := r.Repository.FullName
+		if seen[name] || strings.EqualFold(name, self) || (r.Repository.Fork && !includeForks) {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// runDependents implementa `dependents module/path`: aproxima os
+// repositórios que dependem de um módulo Go pela busca de código e os
+// ordena por estrelas.
+func runDependents(args []string) error {
+	fs := newFlagSet("dependents")
+	limit := fs.Int("limit", 100, "máximo de go.mod analisados pela busca de código (máx. 1000)")
+	top := fs.Int("top", 30, "quantos dependentes mostrar")
+	includeForks := fs.Bool("include-forks", false, "inclui forks entre os dependentes")
+	format := fs.String("format", formatTable, "formato de saída: text, table, csv ou json")
+	fieldsSpec := fs.String("fields", "full_name,stars,pushed_at,url", "colunas para table/csv/json, separadas por vírgula")
+	quiet := fs.Bool("quiet", false, "não mostra o progresso no stderr")
+	cf := addClientFlags(fs)
+
+	// O módulo pode vir antes ou depois das flags.
+	var module string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		module, args = args[0], args[1:]
+	}
+	fs.Parse(args)
+	if module == "" && fs.NArg() > 0 {
+		module = fs.Arg(0)
+	}
+	if !strings.Contains(module, "/") {
+		return invalid(errors.New(tr("uso: dependents module/path (ex: dependents github.com/spf13/cobra)")))
+	}
+	if *limit < 1 || *limit > maxSearchResults {
+		return invalid(fmt.Errorf(tr("-limit deve estar entre 1 e %d"), maxSearchResults))
+	}
+	if *top < 1 {
+		return invalid(errors.New(tr("-top deve ser positivo")))
+	}
+	fields, err := parseFields(*fieldsSpec)
+	if err != nil {
+		return err
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	if client.token == "" && !cf.offline() {
+		return needsAuth(errors.New(tr("a busca de código do GitHub exige autenticação: configure GITHUB_TOKEN ou rode `login`")))
+	}
+	client.SetProgress(newProgressFlag(*quiet))
+	ctx, cancel := cf.context()
+	defer cancel()
+
+	opts := SearchOptions{Query: dependentsQuery(module), PerPage: min(*limit, maxPerPage)}
+	total, results, err := collect(ctx, client.CodeIterator(opts), *limit)
+	if err != nil {
+		return err
+	}
+
+	// A busca de código não traz estrelas: cada repositório custa uma
+	// chamada a /repos. Um repositório que falhar (404, 451, quota...) fica
+	// de fora e é listado no fim; só o cancelamento interrompe.
+	names := dependentRepos(results, module, *includeForks)
+	repos := make([]Repository, 0, len(names))
+	var skipped []Repository
+	client.progress.Start(tr("dependentes"), len(names), tr("repositórios"))
+	for _, name := range names {
+		repo, err := client.GetRepository(ctx, name)
+		client.progress.Step(0)
+		if ctx.Err() != nil {
+			client.progress.Finish()
+			return ctx.Err()
+		}
+		if err != nil {
+			r := Repository{FullName: name}
+			r.markUnavailable("repo", err)
+			skipped = append(skipped, r)
+			continue
+		}
+		repos = append(repos, *repo)
+	}
+	client.progress.Finish()
+	sort.SliceStable(repos, func(a, b int) bool {
+		return repos[a].Stars > repos[b].Stars
+	})
+	if len(repos) > *top {
+		repos = repos[:*top]
+	}
+
+	if *format == formatText || *format == formatTable {
+		fmt.Printf(tr("%d go.mod citam %s; %d repositórios distintos entre os %d analisados.\n\n"), total, module, len(names), len(results))
+	}
+	if err := writeResults(os.Stdout, *format, &SearchResult{TotalCount: len(names), Items: repos}, fields); err != nil {
+		return err
+	}
+	writeSkipped(os.Stderr, skipped)
+	return nil
+}
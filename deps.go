@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// goRequirement é uma linha require de um go.mod.
+type goRequirement struct {
+	Path     string
+	Version  string
+	Indirect bool
+}
+
+// parseGoMod extrai os requires de um go.mod, nas formas de linha única
+// (require x v1) e de bloco (require ( ... )). Diretivas como replace e
+// exclude são ignoradas: o relatório só conta o que o módulo declara usar.
+func parseGoMod(data string) []goRequirement {
+	var reqs []goRequirement
+	inBlock := false
+	for _, line := range strings.Split(data, "\n") {
+		code, comment, _ := strings.Cut(line, "//")
+		fields := strings.Fields(code)
+		switch {
+		case inBlock && len(fields) == 1 && fields[0] == ")":
+			inBlock = false
+			continue
+		case inBlock:
+		case len(fields) == 2 && fields[0] == "require" && fields[1] == "(":
+			inBlock = true
+			continue
+		case len(fields) == 3 && fields[0] == "require":
+			fields = fields[1:]
+		default:
+			continue
+		}
+		if len(fields) != 2 {
+			continue
+		}
+		reqs = append(reqs, goRequirement{
+			Path:     strings.Trim(fields[0], `"`),
+			Version:  fields[1],
+			Indirect: strings.TrimSpace(comment) == "indirect",
+		})
+	}
+	return reqs
+}
+
+// depCount é a frequência de uma dependência entre os repositórios
+// analisados. Versions conta quantos repositórios usam cada versão.
+type depCount struct {
+	Module   string         `json:"module"`
+	Repos    int            `json:"repos"`
+	Share    float64        `json:"share"`
+	Versions map[string]int `json:"versions"`
+	UsedBy   []string       `json:"used_by"`
+}
+
+// commonVersion é a versão usada pelo maior número de repositórios
+// (empates pela maior string, que costuma ser a mais nova).
+func (d depCount) commonVersion() string {
+	best, n := "", 0
+	for v, c := range d.Versions {
+		if c > n || (c == n && v > best) {
+			best, n = v, c
+		}
+	}
+	return best
+}
+
+// depReport é o relatório combinado: as dependências mais usadas entre os
+// repositórios que têm go.mod.
+type depReport struct {
+	Query        string     `json:"query"`
+	Analyzed     int        `json:"repos_analyzed"`
+	WithoutGoMod []string   `json:"repos_without_go_mod"`
+	Dependencies []depCount `json:"dependencies"`
+}
+
+// countDeps agrega os requires por módulo e devolve os top mais
+// frequentes (empates em ordem alfabética). Indiretas só entram com
+// indirect ligado.
+func countDeps(mods map[string][]goRequirement, analyzed int, indirect bool, top int) []depCount {
+	byModule := map[string]*depCount{}
+	names := make([]string, 0, len(mods))
+	for name := range mods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, req := range mods[name] {
+			if req.Indirect && !indirect {
+				continue
+			}
+			d := byModule[req.Path]
+			if d == nil {
+				d = &depCount{Module: req.Path, Versions: map[string]int{}}
+				byModule[req.Path] = d
+			}
+			d.Repos++
+			d.Versions[req.Version]++
+			d.UsedBy = append(d.UsedBy, name)
+		}
+	}
+
+	deps := make([]depCount, 0, len(byModule))
+	for _, d := range byModule {
+		if analyzed > 0 {
+			d.Share = float64(d.Repos) / float64(analyzed)
+		}
+		deps = append(deps, *d)
+	}
+	sort.Slice(deps, func(a, b int) bool {
+		if deps[a].Repos != deps[b].Repos {
+			return deps[a].Repos > deps[b].Repos
+		}
+		return deps[a].Module < deps[b].Module
+	})
+	return deps[:min(top, len(deps))]
+}
+
+// writeDepReport escreve o relatório; em text, com barras proporcionais à
+// frequência, como a nuvem de tópicos.
+func writeDepReport(w io.Writer, format string, rep *depReport) error {
+	switch format {
+	case formatText, formatTable:
+		fmt.Fprintf(w, tr("%d repositórios com go.mod analisados (%d sem go.mod na raiz).\n\n"), rep.Analyzed, len(rep.WithoutGoMod))
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, tr("MÓDULO\tREPOSITÓRIOS\t%\tVERSÃO MAIS USADA\t"))
+		for _, d := range rep.Dependencies {
+			bar := ""
+			if format == formatText {
+				bar = strings.Repeat("█", max(1, d.Repos*30/rep.Dependencies[0].Repos))
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%.0f%%\t%s\t%s\n", d.Module, d.Repos, d.Share*100, d.commonVersion(), bar)
+		}
+		return tw.Flush()
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"module", "repos", "share", "common_version", "used_by"})
+		for _, d := range rep.Dependencies {
+			cw.Write([]string{d.Module, strconv.Itoa(d.Repos), strconv.FormatFloat(d.Share, 'f', 3, 64), d.commonVersion(), strings.Join(d.UsedBy, " ")})
+		}
+		cw.Flush()
+		return cw.Error()
+	case formatJSON:
+		return encodeJSON(w, rep)
+	}
+	return fmt.Errorf(tr("formato desconhecido: %q (use text, table, csv ou json)"), format)
+}
+
+// runDeps implementa `deps -q "..."`: baixa o go.mod dos N primeiros
+// repositórios Go da busca e mostra as dependências mais comuns entre
+// eles, uma espécie de SBOM do ecossistema.
+func runDeps(args []string) error {
+	fs := newFlagSet("deps")
+	sf := addSearchFlags(fs, 30)
+	top := fs.Int("top", 30, "quantas dependências mostrar")
+	indirect := fs.Bool("indirect", false, "conta também as dependências marcadas // indirect")
+	format := fs.String("format", formatText, "formato de saída: text, table, csv ou json")
+	cf := addClientFlags(fs)
+	fs.Parse(args)
+
+	if err := sf.validate(); err != nil {
+		return err
+	}
+	if err := validateQuery(sf.query); err != nil {
+		return err
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := cf.context()
+	defer cancel()
+
+	// Só repositórios Go têm go.mod; a query ganha language:go se não
+	// restringir a linguagem por conta própria.
+	opts := sf.options()
+	opts.Query = withQualifiers(opts.Query, "language:go")
+	result, err := client.SearchAllRepositories(ctx, opts, sf.limit)
+	if err != nil {
+		return err
+	}
+
+	// Cada repositório custa uma chamada a /contents/go.mod.
+	rep := &depReport{Query: opts.Query, WithoutGoMod: []string{}}
+	mods := map[string][]goRequirement{}
+	for i, r := range result.Items {
+		log.Printf(tr("Buscando %d/%d: %s"), i+1, len(result.Items), r.FullName)
+		data, err := client.GetFile(ctx, r.FullName, "go.mod")
+		if err != nil {
+			return fmt.Errorf("%s: %w", r.FullName, err)
+		}
+		if data == "" {
+			rep.WithoutGoMod = append(rep.WithoutGoMod, r.FullName)
+			continue
+		}
+		mods[r.FullName] = parseGoMod(data)
+	}
+	rep.Analyzed = len(mods)
+	rep.Dependencies = countDeps(mods, rep.Analyzed, *indirect, *top)
+	return writeDepReport(os.Stdout, *format, rep)
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// repoDelta é a variação das métricas de um repositório presente nos dois
+// snapshots. Rank é a posição no resultado (1 = primeiro); RankDelta
+// positivo significa que o repositório subiu.
+type repoDelta struct {
+	FullName   string `json:"full_name"`
+	Stars      int    `json:"stars"`
+	Forks      int    `json:"forks"`
+	OpenIssues int    `json:"open_issues"`
+	Rank       int    `json:"rank"`
+	RankDelta  int    `json:"rank_delta"`
+	StarsFrom  int    `json:"stars_from"`
+	StarsTo    int    `json:"stars_to"`
+}
+
+// changed informa se alguma métrica mudou. Só a posição mudar não conta:
+// um repositório novo no topo deslocaria todos os outros.
+func (d repoDelta) changed() bool {
+	return d.Stars != 0 || d.Forks != 0 || d.OpenIssues != 0
+}
+
+// snapshotDiff é a comparação entre dois snapshots do mesmo resultado.
+type snapshotDiff struct {
+	From    snapshot     `json:"-"`
+	To      snapshot     `json:"-"`
+	Added   []Repository `json:"added"`
+	Removed []Repository `json:"removed"`
+	Changed []repoDelta  `json:"changed"`
+}
+
+// empty informa se os dois snapshots têm os mesmos repositórios com as
+// mesmas métricas.
+func (d snapshotDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// diffSnapshots compara from com to. Novos e removidos seguem a ordem do
+// snapshot onde aparecem; alterados vêm da maior variação de estrelas para
+// a menor.
+func diffSnapshots(from, to snapshot) snapshotDiff {
+	d := snapshotDiff{From: from, To: to, Added: []Repository{}, Removed: []Repository{}, Changed: []repoDelta{}}
+	before := map[string]int{}
+	for i, r := range from.Items {
+		before[strings.ToLower(r.FullName)] = i
+	}
+	after := map[string]bool{}
+	for i, r := range to.Items {
+		key := strings.ToLower(r.FullName)
+		after[key] = true
+		j, ok := before[key]
+		if !ok {
+			d.Added = append(d.Added, r)
+			continue
+		}
+		old := from.Items[j]
+		delta := repoDelta{
+			FullName:   r.FullName,
+			Stars:      r.Stars - old.Stars,
+			Forks:      r.Forks - old.Forks,
+			OpenIssues: r.OpenIssues - old.OpenIssues,
+			Rank:       i + 1,
+			RankDelta:  j - i,
+			StarsFrom:  old.Stars,
+			StarsTo:    r.Stars,
+		}
+		if delta.changed() {
+			d.Changed = append(d.Changed, delta)
+		}
+	}
+	for _, r := range from.Items {
+		if !after[strings.ToLower(r.FullName)] {
+			d.Removed = append(d.Removed, r)
+		}
+	}
+	sort.SliceStable(d.Changed, func(a, b int) bool {
+		return d.Changed[a].Stars > d.Changed[b].Stars
+	})
+	return d
+}
+
+// Summary resume a comparação em uma linha, para logs.
+func (d snapshotDiff) Summary() string {
+	return fmt.Sprintf(tr("%d novos, %d removidos, %d alterados"), len(d.Added), len(d.Removed), len(d.Changed))
+}
+
+// loadSnapshot lê um resultado salvo: um snapshot do daemon ou de -sink
+// (em JSON Lines, vale a última linha) ou a saída de `-format json`.
+func loadSnapshot(path string) (snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snapshot{}, fmt.Errorf(tr("falha ao ler %s: %w"), path, err)
+	}
+	// Em JSON Lines, cada linha é um snapshot completo; o mais recente é
+	// o último.
+	var last json.RawMessage
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var v json.RawMessage
+		if err := dec.Decode(&v); err == io.EOF {
+			break
+		} else if err != nil {
+			return snapshot{}, fmt.Errorf(tr("falha ao decodificar %s: %w"), path, err)
+		}
+		last = v
+	}
+	if last == nil {
+		return snapshot{}, fmt.Errorf(tr("%s está vazio"), path)
+	}
+	snap, err := decodeSnapshot(last)
+	if err != nil {
+		return snapshot{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return snap, nil
+}
+
+// decodeSnapshot aceita tanto os itens do snapshot (campos da API, como
+// stargazers_count) quanto as linhas de `-format json` (stars, forks...).
+func decodeSnapshot(data []byte) (snapshot, error) {
+	var raw struct {
+		snapshot
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return snapshot{}, fmt.Errorf(tr("falha ao decodificar resultado: %w"), err)
+	}
+	if raw.Items == nil {
+		return snapshot{}, errors.New(tr("o arquivo não tem items; use um snapshot do daemon/-sink ou a saída de -format json"))
+	}
+	snap := raw.snapshot
+	snap.Items = make([]Repository, 0, len(raw.Items))
+	for i, item := range raw.Items {
+		var r Repository
+		var row struct {
+			Stars      *int   `json:"stars"`
+			Forks      *int   `json:"forks"`
+			OpenIssues *int   `json:"open_issues"`
+			URL        string `json:"url"`
+		}
+		if err := json.Unmarshal(item, &r); err != nil {
+			return snapshot{}, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		json.Unmarshal(item, &row)
+		if row.Stars != nil {
+			r.Stars = *row.Stars
+		}
+		if row.Forks != nil {
+			r.Forks = *row.Forks
+		}
+		if row.OpenIssues != nil {
+			r.OpenIssues = *row.OpenIssues
+		}
+		if r.URL == "" {
+			r.URL = row.URL
+		}
+		if r.FullName == "" {
+			return snapshot{}, fmt.Errorf(tr("item %d sem full_name (inclua a coluna em -fields)"), i+1)
+		}
+		snap.Items = append(snap.Items, r)
+	}
+	return snap, nil
+}
+
+// writeDiff imprime a comparação para leitura humana.
+func writeDiff(w io.Writer, d snapshotDiff) {
+	label := func(s snapshot) string {
+		if s.TakenAt.IsZero() {
+			return fmt.Sprintf(tr("%d repositórios"), len(s.Items))
+		}
+		return fmt.Sprintf(tr("%d repositórios em %s"), len(s.Items), s.TakenAt.Format("2006-01-02 15:04"))
+	}
+	fmt.Fprintf(w, tr("De %s para %s: %s\n"), label(d.From), label(d.To), d.Summary())
+	if d.empty() {
+		return
+	}
+	if len(d.Added) > 0 {
+		fmt.Fprint(w, tr("\nNovos:\n"))
+		for _, r := range d.Added {
+			fmt.Fprintf(w, "  + %s ⭐ %d\n", r.FullName, r.Stars)
+		}
+	}
+	if len(d.Removed) > 0 {
+		fmt.Fprint(w, tr("\nRemovidos:\n"))
+		for _, r := range d.Removed {
+			fmt.Fprintf(w, "  - %s ⭐ %d\n", r.FullName, r.Stars)
+		}
+	}
+	if len(d.Changed) > 0 {
+		fmt.Fprint(w, tr("\nAlterados:\n"))
+		for _, c := range d.Changed {
+			fmt.Fprintf(w, tr("  %s ⭐ %+d (%d → %d) · forks %+d · issues %+d · posição %d (%+d)\n"),
+				c.FullName, c.Stars, c.StarsFrom, c.StarsTo, c.Forks, c.OpenIssues, c.Rank, c.RankDelta)
+		}
+	}
+}
+
+// runDiff implementa `diff A.json B.json`: compara dois resultados salvos
+// e mostra repositórios novos, removidos e a variação das métricas.
+func runDiff(args []string) error {
+	fs := newFlagSet("diff")
+	format := fs.String("format", formatText, "formato de saída: text ou json")
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		return invalid(errors.New(tr("uso: diff snapshotA.json snapshotB.json")))
+	}
+	if *format != formatText && *format != formatJSON {
+		return invalid(fmt.Errorf(tr("-format aceita text ou json, recebido %q"), *format))
+	}
+	from, err := loadSnapshot(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	to, err := loadSnapshot(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	d := diffSnapshots(from, to)
+	if *format == formatJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	}
+	writeDiff(os.Stdout, d)
+	return nil
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// enrichers lista as etapas disponíveis em -enrich.
+var enrichers = []Stage{
+	funcStage{name: "languages", calls: 1, fn: enrichLanguages},
+	funcStage{name: "release", calls: 1, fn: enrichRelease},
+	funcStage{name: "ci", calls: 1, fn: enrichCI},
+	funcStage{name: "security", calls: 2, workers: 2, fn: enrichSecurity},
+	funcStage{name: "activity", calls: 1, fn: enrichActivity},
+	funcStage{name: "heatmap", calls: 1, fn: enrichHeatmap},
+}
+
+// parseEnrichers converte "ci,security" na lista de etapas, ignorando
+// repetições.
+func parseEnrichers(spec string) ([]Stage, error) {
+	var list []Stage
+	seen := map[string]bool{}
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		s, ok := lookupEnricher(name)
+		if !ok {
+			return nil, invalid(fmt.Errorf(tr("enriquecimento desconhecido em -enrich: %q (disponíveis: %s)"), name, enricherNames()))
+		}
+		seen[name] = true
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+func lookupEnricher(name string) (Stage, bool) {
+	for _, s := range enrichers {
+		if s.Name() == name {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+func enricherNames() string {
+	names := make([]string, len(enrichers))
+	for i, s := range enrichers {
+		names[i] = s.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+// enrichCalls soma as chamadas por repositório de uma lista.
+func enrichCalls(list []Stage) int {
+	n := 0
+	for _, s := range list {
+		n += s.Calls()
+	}
+	return n
+}
+
+// markUnavailable registra que o enriquecimento name falhou para r.
+func (r *Repository) markUnavailable(name string, err error) {
+	if r.Unavailable == nil {
+		r.Unavailable = map[string]string{}
+	}
+	r.Unavailable[name] = failureReason(err)
+}
+
+// unavailable informa se o enriquecimento name falhou para r.
+func (r Repository) unavailable(name string) bool {
+	_, ok := r.Unavailable[name]
+	return ok
+}
+
+// failureReason resume o erro de um enriquecimento: o status HTTP quando a
+// API respondeu, ou a mensagem do erro.
+func failureReason(err error) string {
+	if isRateLimited(err) {
+		return tr("quota esgotada")
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return tr("tempo esgotado")
+	}
+	return err.Error()
+}
+
+// writeSkipped lista, ao fim da execução, os enriquecimentos que ficaram
+// indisponíveis e por quê. Não escreve nada se não houve falhas.
+func writeSkipped(w io.Writer, repos []Repository) {
+	var lines []string
+	for _, r := range repos {
+		names := make([]string, 0, len(r.Unavailable))
+		for name := range r.Unavailable {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			lines = append(lines, fmt.Sprintf("  %s (%s): %s", r.FullName, name, r.Unavailable[name]))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintf(w, tr("\n%d enriquecimento(s) indisponível(is):\n%s\n"), len(lines), strings.Join(lines, "\n"))
+}
+
+// Valores de Repository.CIStatus além das conclusões do GitHub
+// (success, failure, cancelled...).
+const (
+	ciNone    = "none"    // nenhum workflow executado na branch padrão
+	ciRunning = "running" // última execução ainda não terminou
+)
+
+// unavailableValue é exibido no lugar de um enriquecimento que falhou.
+const unavailableValue = "unavailable"
+
+// enrichCI consulta a execução mais recente do GitHub Actions na
+// branch padrão.
+func enrichCI(ctx context.Context, c *Client, r *Repository) error {
+	params := url.Values{}
+	params.Add("per_page", "1")
+	if r.DefaultBranch != "" {
+		params.Add("branch", r.DefaultBranch)
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, repoPath(r.FullName, "/actions/runs"), params)
+	if err != nil {
+		return err
+	}
+
+	var payload struct {
+		WorkflowRuns []struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"workflow_runs"`
+	}
+	if _, err := c.do(req, &payload); err != nil {
+		return err
+	}
+
+	switch {
+	case len(payload.WorkflowRuns) == 0:
+		r.CIStatus = ciNone
+	case payload.WorkflowRuns[0].Status != "completed":
+		r.CIStatus = ciRunning
+	default:
+		r.CIStatus = payload.WorkflowRuns[0].Conclusion
+	}
+	return nil
+}
+
+// enrichLanguages busca os bytes de código por linguagem, que mostram o
+// que há no repositório além da linguagem principal da busca.
+func enrichLanguages(ctx context.Context, c *Client, r *Repository) error {
+	req, err := c.newRequest(ctx, http.MethodGet, repoPath(r.FullName, "/languages"), nil)
+	if err != nil {
+		return err
+	}
+	var languages map[string]int
+	if _, err := c.do(req, &languages); err != nil {
+		return err
+	}
+	r.Languages = languages
+	return nil
+}
+
+// languagesSummary mostra as três linguagens com mais código e a fatia
+// de cada uma; vazio quando o enriquecimento não foi pedido.
+func languagesSummary(r Repository) string {
+	if r.unavailable("languages") {
+		return unavailableValue
+	}
+	total := 0
+	names := make([]string, 0, len(r.Languages))
+	for name, bytes := range r.Languages {
+		names = append(names, name)
+		total += bytes
+	}
+	if total == 0 {
+		return ""
+	}
+	sort.Slice(names, func(a, b int) bool {
+		if r.Languages[names[a]] != r.Languages[names[b]] {
+			return r.Languages[names[a]] > r.Languages[names[b]]
+		}
+		return names[a] < names[b]
+	})
+	parts := make([]string, 0, 3)
+	for _, name := range names[:min(3, len(names))] {
+		pct := 100 * float64(r.Languages[name]) / float64(total)
+		if pct < 0.5 {
+			parts = append(parts, name+" <1%")
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", name, pct))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// enrichRelease busca a release mais recente. Repositórios sem releases
+// ficam com LatestRelease nil.
+func enrichRelease(ctx context.Context, c *Client, r *Repository) error {
+	release, err := c.GetLatestRelease(ctx, r.FullName)
+	if err != nil {
+		return err
+	}
+	r.LatestRelease = release
+	return nil
+}
+
+// releaseSummary mostra a tag e a data da última release.
+func releaseSummary(r Repository) string {
+	if r.unavailable("release") {
+		return unavailableValue
+	}
+	if r.LatestRelease == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s)", r.LatestRelease.TagName, r.LatestRelease.PublishedAt.Format("2006-01-02"))
+}
+
+// enrichActivity busca a data do último commit na branch padrão. O
+// pushed_at da busca muda com push em qualquer branch (inclusive de bots),
+// então o commit é o que confirma que o projeto é mantido. Repositórios
+// vazios (409) ficam sem data.
+func enrichActivity(ctx context.Context, c *Client, r *Repository) error {
+	params := url.Values{}
+	params.Add("per_page", "1")
+	if r.DefaultBranch != "" {
+		params.Add("sha", r.DefaultBranch)
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, repoPath(r.FullName, "/commits"), params)
+	if err != nil {
+		return err
+	}
+
+	var commits []struct {
+		Commit struct {
+			Committer struct {
+				Date time.Time `json:"date"`
+			} `json:"committer"`
+		} `json:"commit"`
+	}
+	_, err = c.do(req, &commits)
+	if isStatus(err, http.StatusConflict) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(commits) > 0 {
+		r.LastCommitAt = &commits[0].Commit.Committer.Date
+	}
+	return nil
+}
+
+// enrichSecurity conta os security advisories publicados do repositório
+// e, quando o token tem permissão, os alertas abertos do Dependabot.
+// Sem permissão (403/404), DependabotAlerts fica nil em vez de falhar.
+func enrichSecurity(ctx context.Context, c *Client, r *Repository) error {
+	advisories, err := c.countList(ctx, repoPath(r.FullName, "/security-advisories"), url.Values{"state": {"published"}})
+	if err != nil {
+		return err
+	}
+	r.Advisories = &advisories
+
+	alerts, err := c.countList(ctx, repoPath(r.FullName, "/dependabot/alerts"), url.Values{"state": {"open"}})
+	switch {
+	case isStatus(err, http.StatusForbidden), isStatus(err, http.StatusNotFound), isStatus(err, http.StatusUnauthorized):
+		r.DependabotAlerts = nil
+	case err != nil:
+		return err
+	default:
+		r.DependabotAlerts = &alerts
+	}
+	return nil
+}
+
+// countList conta os itens da primeira página (até 100) de um endpoint
+// que devolve uma lista JSON.
+func (c *Client) countList(ctx context.Context, path string, params url.Values) (int, error) {
+	params.Set("per_page", "100")
+	req, err := c.newRequest(ctx, http.MethodGet, path, params)
+	if err != nil {
+		return 0, err
+	}
+	var items []json.RawMessage
+	if _, err := c.do(req, &items); err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+// securitySummary resume o enriquecimento de segurança para exibição;
+// vazio quando o enriquecimento não foi pedido.
+func securitySummary(r Repository) string {
+	if r.unavailable("security") {
+		return unavailableValue
+	}
+	if r.Advisories == nil {
+		return ""
+	}
+	s := fmt.Sprintf("advisories=%d", *r.Advisories)
+	if r.DependabotAlerts != nil {
+		s += fmt.Sprintf(" alerts=%d", *r.DependabotAlerts)
+	}
+	if *r.Advisories > 0 || (r.DependabotAlerts != nil && *r.DependabotAlerts > 0) {
+		s = "⚠ " + s
+	}
+	return s
+}
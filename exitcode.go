@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Códigos de saída do programa, para scripts e CI distinguirem o motivo de
+// uma falha sem ler a mensagem. O 2 é o mesmo que o pacote flag usa para
+// flags inválidas.
+const (
+	exitError       = 1 // falha sem classe própria
+	exitUsage       = 2 // flag, argumento ou query inválidos
+	exitAuth        = 3 // token ausente, inválido ou sem permissão
+	exitRateLimited = 4 // quota da API esgotada
+	exitNetwork     = 5 // sem conexão, DNS, prazo esgotado
+	exitNoResults   = 6 // a busca não encontrou nada
+)
+
+// Classes de erro, como aparecem no campo "error" de -error-format json.
+const (
+	kindError       = "error"
+	kindUsage       = "usage"
+	kindAuth        = "auth"
+	kindRateLimited = "rate_limited"
+	kindNetwork     = "network"
+	kindNoResults   = "no_results"
+)
+
+// errorFormat é o formato das mensagens de erro em stderr: text ou json.
+var errorFormat = "text"
+
+func setErrorFormat(value string) error {
+	switch v := strings.ToLower(value); v {
+	case "text", "json":
+		errorFormat = v
+		return nil
+	}
+	return fmt.Errorf(tr("-error-format aceita text ou json, recebido %q"), value)
+}
+
+// classedError marca err com uma classe que não se deduz do próprio erro,
+// como uma flag inválida ou a falta de token.
+type classedError struct {
+	kind string
+	err  error
+}
+
+func (e *classedError) Error() string { return e.err.Error() }
+func (e *classedError) Unwrap() error { return e.err }
+
+// invalid marca err como erro de uso (flag, argumento ou query inválidos).
+func invalid(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classedError{kind: kindUsage, err: err}
+}
+
+// needsAuth marca err como falta de autenticação.
+func needsAuth(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classedError{kind: kindAuth, err: err}
+}
+
+// noResultsError é devolvido quando a busca termina sem nenhum
+// repositório. query fica vazia no modo lote.
+type noResultsError struct {
+	query string
+}
+
+func (e *noResultsError) Error() string {
+	if e.query == "" {
+		return tr("nenhum repositório encontrado")
+	}
+	return fmt.Sprintf(tr("nenhum repositório encontrado para %q"), e.query)
+}
+
+// isRateLimited informa se err é a resposta da API para quota esgotada:
+// 403 com "rate limit" na mensagem (limites primário e secundário) ou 429.
+func isRateLimited(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusTooManyRequests ||
+		apiErr.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(apiErr.Message), "rate limit")
+}
+
+// classifyError devolve a classe de err e o código de saída correspondente.
+func classifyError(err error) (kind string, code int) {
+	var classed *classedError
+	var apiErr *APIError
+	var noResults *noResultsError
+	var urlErr *url.Error
+	var netErr net.Error
+	switch {
+	case errors.As(err, &classed):
+		kind = classed.kind
+	case errors.As(err, &noResults):
+		kind = kindNoResults
+	case isRateLimited(err):
+		kind = kindRateLimited
+	case errors.As(err, &apiErr):
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			kind = kindAuth
+		case http.StatusUnprocessableEntity:
+			kind = kindUsage
+		default:
+			kind = kindError
+		}
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &urlErr), errors.As(err, &netErr):
+		kind = kindNetwork
+	default:
+		kind = kindError
+	}
+	return kind, exitCodes[kind]
+}
+
+var exitCodes = map[string]int{
+	kindError:       exitError,
+	kindUsage:       exitUsage,
+	kindAuth:        exitAuth,
+	kindRateLimited: exitRateLimited,
+	kindNetwork:     exitNetwork,
+	kindNoResults:   exitNoResults,
+}
+
+// errorReport é o objeto que -error-format json escreve em stderr.
+type errorReport struct {
+	Error   string `json:"error"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  int    `json:"status,omitempty"` // status HTTP, quando veio da API
+}
+
+// exitWithError escreve err em stderr, no formato de -error-format, e
+// encerra o programa com o código da classe do erro.
+func exitWithError(err error) {
+	kind, code := classifyError(err)
+	if errorFormat != "json" {
+		log.Printf(tr("ERRO: %v"), err)
+		os.Exit(code)
+	}
+
+	report := errorReport{Error: kind, Code: code, Message: err.Error()}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		report.Status = apiErr.StatusCode
+	}
+	json.NewEncoder(os.Stderr).Encode(report)
+	os.Exit(code)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// repoBundle é tudo o que o export salva de um repositório.
+type repoBundle struct {
+	Repository Repository `json:"repository"`
+	Topics     []string   `json:"topics"`
+	Readme     string     `json:"readme"`
+}
+
+// runExport implementa o subcomando `export`: busca os repositórios e
+// grava um catálogo navegável em -out, com um arquivo por repositório e
+// um index.md apontando para todos.
+func runExport(args []string) error {
+	fs := newFlagSet("export")
+	sf := addSearchFlags(fs, 10)
+	outDir := fs.String("out", "catalog", "diretório de saída")
+	format := fs.String("format", "both", "formato dos arquivos: json, markdown ou both")
+	cf := addClientFlags(fs)
+	fs.Parse(args)
+
+	if err := sf.validate(); err != nil {
+		return err
+	}
+	writeJSONFiles := *format == "json" || *format == "both"
+	writeMarkdown := *format == "markdown" || *format == "both"
+	if !writeJSONFiles && !writeMarkdown {
+		return invalid(fmt.Errorf(tr("formato desconhecido: %q (use json, markdown ou both)"), *format))
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := cf.context()
+	defer cancel()
+
+	result, err := client.SearchAllRepositories(ctx, sf.options(), sf.limit)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		return fmt.Errorf(tr("falha ao criar diretório de saída: %w"), err)
+	}
+
+	var index strings.Builder
+	fmt.Fprintf(&index, tr("# Catálogo: `%s`\n\n"), sf.query)
+	for i, repo := range result.Items {
+		log.Printf(tr("Exportando %d/%d: %s"), i+1, len(result.Items), repo.FullName)
+
+		bundle, err := fetchBundle(ctx, client, repo)
+		if err != nil {
+			return fmt.Errorf("%s: %w", repo.FullName, err)
+		}
+
+		slug := strings.ReplaceAll(repo.FullName, "/", "__")
+		if writeJSONFiles {
+			data, err := json.MarshalIndent(bundle, "", "  ")
+			if err != nil {
+				return fmt.Errorf(tr("falha ao codificar JSON: %w"), err)
+			}
+			if err := os.WriteFile(filepath.Join(*outDir, slug+".json"), data, 0o644); err != nil {
+				return fmt.Errorf(tr("falha ao salvar %s: %w"), repo.FullName, err)
+			}
+		}
+		link := slug + ".json"
+		if writeMarkdown {
+			if err := os.WriteFile(filepath.Join(*outDir, slug+".md"), []byte(bundleMarkdown(bundle)), 0o644); err != nil {
+				return fmt.Errorf(tr("falha ao salvar %s: %w"), repo.FullName, err)
+			}
+			link = slug + ".md"
+		}
+		fmt.Fprintf(&index, "- [%s](%s) ⭐ %d — %s\n", repo.FullName, link, repo.Stars, repo.Description)
+	}
+
+	if err := os.WriteFile(filepath.Join(*outDir, "index.md"), []byte(index.String()), 0o644); err != nil {
+		return fmt.Errorf(tr("falha ao salvar índice: %w"), err)
+	}
+	fmt.Printf(tr("%d repositórios exportados para %s\n"), len(result.Items), *outDir)
+	return nil
+}
+
+// fetchBundle busca o README e os tópicos de um repositório. A licença
+// já vem no resultado da busca.
+func fetchBundle(ctx context.Context, client *Client, repo Repository) (*repoBundle, error) {
+	readme, err := client.GetReadme(ctx, repo.FullName)
+	if err != nil {
+		return nil, fmt.Errorf("README: %w", err)
+	}
+	topics, err := client.GetTopics(ctx, repo.FullName)
+	if err != nil {
+		return nil, fmt.Errorf(tr("tópicos: %w"), err)
+	}
+	return &repoBundle{Repository: repo, Topics: topics, Readme: readme}, nil
+}
+
+// bundleMarkdown gera a página de um repositório no catálogo.
+func bundleMarkdown(b *repoBundle) string {
+	r := b.Repository
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", r.FullName)
+	if r.Description != "" {
+		fmt.Fprintf(&sb, "> %s\n\n", r.Description)
+	}
+	fmt.Fprintf(&sb, "- URL: %s\n", r.URL)
+	fmt.Fprintf(&sb, tr("- Estrelas: %d · Forks: %d · Issues abertas: %d\n"), r.Stars, r.Forks, r.OpenIssues)
+	if r.Language != "" {
+		fmt.Fprintf(&sb, tr("- Linguagem: %s\n"), r.Language)
+	}
+	license := tr("nenhuma detectada")
+	if r.License != nil {
+		license = r.License.Name
+	}
+	fmt.Fprintf(&sb, tr("- Licença: %s\n"), license)
+	if len(b.Topics) > 0 {
+		fmt.Fprintf(&sb, tr("- Tópicos: %s\n"), strings.Join(b.Topics, ", "))
+	}
+	if !r.PushedAt.IsZero() {
+		fmt.Fprintf(&sb, tr("- Último push: %s\n"), r.PushedAt.Format("2006-01-02"))
+	}
+
+	sb.WriteString("\n---\n\n")
+	if b.Readme != "" {
+		sb.WriteString(b.Readme)
+	} else {
+		sb.WriteString(tr("_Sem README._\n"))
+	}
+	return sb.String()
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseAge interpreta durações como "90d", "6w", "1y" ou qualquer valor
+// aceito por time.ParseDuration ("36h"). Meses e anos são aproximados
+// em 30 e 365 dias.
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	units := map[byte]time.Duration{
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+		'm': 30 * 24 * time.Hour,
+		'y': 365 * 24 * time.Hour,
+	}
+	if unit, ok := units[s[len(s)-1]]; ok {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err == nil && n >= 0 {
+			return time.Duration(n) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, invalid(fmt.Errorf(tr("duração inválida %q (use por exemplo 90d, 6w, 1y)"), s))
+	}
+	return d, nil
+}
+
+// dateFilter descarta, no cliente, repositórios fora das janelas de tempo.
+// Janelas zeradas não filtram nada.
+type dateFilter struct {
+	PushedWithin  time.Duration
+	UpdatedWithin time.Duration
+	CreatedWithin time.Duration
+}
+
+func (f dateFilter) keep(r Repository, now time.Time) bool {
+	within := func(t time.Time, d time.Duration) bool {
+		return d == 0 || (!t.IsZero() && now.Sub(t) <= d)
+	}
+	return within(r.PushedAt, f.PushedWithin) &&
+		within(r.UpdatedAt, f.UpdatedWithin) &&
+		within(r.CreatedAt, f.CreatedWithin)
+}
+
+// filterByDate devolve apenas os repositórios aceitos pelo filtro.
+func filterByDate(repos []Repository, f dateFilter, now time.Time) []Repository {
+	kept := repos[:0:0]
+	for _, r := range repos {
+		if f.keep(r, now) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// filterByScore descarta os repositórios com relevância abaixo de minScore; 0
+// não filtra. O score só varia entre itens com -sort best-match: nas
+// outras ordenações a API devolve 1 para todos.
+func filterByScore(repos []Repository, minScore float64) []Repository {
+	if minScore <= 0 {
+		return repos
+	}
+	kept := repos[:0:0]
+	for _, r := range repos {
+		if r.Score >= minScore {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// filterBySize descarta os repositórios maiores que maxKB (o size da API
+// é em KB); 0 não filtra.
+func filterBySize(repos []Repository, maxKB int) []Repository {
+	if maxKB <= 0 {
+		return repos
+	}
+	kept := repos[:0:0]
+	for _, r := range repos {
+		if r.Size <= maxKB {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// formatSize mostra um tamanho em KB na maior unidade que faça sentido.
+func formatSize(kb int) string {
+	switch {
+	case kb >= 1<<20:
+		return fmt.Sprintf("%.1f GB", float64(kb)/(1<<20))
+	case kb >= 1<<10:
+		return fmt.Sprintf("%.1f MB", float64(kb)/(1<<10))
+	}
+	return fmt.Sprintf("%d KB", kb)
+}
+
+// filterActive confirma, no cliente, o que -active pediu na query:
+// descarta arquivados e, quando o enriquecimento activity trouxe a data do
+// último commit, os que não têm commit na branch padrão dentro da janela.
+func filterActive(repos []Repository, within time.Duration, now time.Time) []Repository {
+	kept := repos[:0:0]
+	for _, r := range repos {
+		if r.Archived || (r.LastCommitAt != nil && now.Sub(*r.LastCommitAt) > within) {
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// healthHalfLife é o tempo sem push após o qual o peso da recência cai
+// pela metade no health score.
+const healthHalfLife = 180 * 24 * time.Hour
+
+// healthScore combina popularidade e atividade: log10 das estrelas
+// multiplicado por um fator de recência que decai exponencialmente com o
+// tempo desde o último push. Um projeto popular mas parado há anos perde
+// para um projeto menor e ativo.
+func healthScore(r Repository, now time.Time) float64 {
+	popularity := logCount(r.Stars)
+	return math.Round(popularity*recency(r, now)*1000) / 1000
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+// rankedIssue é uma issue acompanhada das estrelas do seu repositório.
+type rankedIssue struct {
+	Issue Issue
+	Repo  string
+	Stars int
+}
+
+// runFirstIssues implementa o subcomando `first-issues`: busca issues
+// "good first issue" abertas e as ordena pela popularidade do projeto.
+func runFirstIssues(args []string) error {
+	fs := newFlagSet("first-issues")
+	language := fs.String("language", "go", "linguagem dos repositórios")
+	label := fs.String("label", "good first issue", "label que marca issues para iniciantes")
+	limit := fs.Int("limit", 100, "issues buscadas antes do ranking (máx. 1000)")
+	top := fs.Int("top", 20, "quantas issues mostrar")
+	minStars := fs.Int("min-stars", 0, "ignora repositórios com menos estrelas")
+	cf := addClientFlags(fs)
+	fs.Parse(args)
+	if *limit < 1 || *limit > maxSearchResults {
+		return invalid(fmt.Errorf(tr("-limit deve estar entre 1 e %d"), maxSearchResults))
+	}
+	if *top < 1 {
+		return invalid(errors.New(tr("-top deve ser positivo")))
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := cf.context()
+	defer cancel()
+
+	opts := SearchOptions{
+		Query:   fmt.Sprintf("label:%q state:open is:issue language:%s", *label, *language),
+		Sort:    "created",
+		Order:   "desc",
+		PerPage: min(*limit, maxPerPage),
+	}
+	result, err := client.SearchAllIssues(ctx, opts, *limit)
+	if err != nil {
+		return err
+	}
+
+	ranked, skipped, err := rankByRepoStars(ctx, client, result.Items)
+	if err != nil {
+		return err
+	}
+	kept := ranked[:0]
+	for _, r := range ranked {
+		if r.Stars >= *minStars {
+			kept = append(kept, r)
+		}
+	}
+	if err := writeRankedIssues(os.Stdout, kept[:min(*top, len(kept))]); err != nil {
+		return err
+	}
+	writeSkipped(os.Stderr, skipped)
+	return nil
+}
+
+// rankByRepoStars consulta cada repositório uma única vez e ordena as
+// issues pelas estrelas do repositório; no empate, as menos comentadas
+// (ainda sem ninguém trabalhando nelas) vêm primeiro. Um repositório que
+// não pôde ser consultado (404, 451, quota...) fica de fora, com as suas
+// issues, e volta em skipped com o motivo; só o cancelamento de ctx é erro.
+func rankByRepoStars(ctx context.Context, client *Client, issues []Issue) (ranked []rankedIssue, skipped []Repository, err error) {
+	stars := map[string]int{}
+	failed := map[string]bool{}
+	ranked = make([]rankedIssue, 0, len(issues))
+	for _, issue := range issues {
+		name := issue.RepoFullName()
+		if failed[name] {
+			continue
+		}
+		if _, ok := stars[name]; !ok {
+			repo, err := client.GetRepository(ctx, name)
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+			if err != nil {
+				failed[name] = true
+				r := Repository{FullName: name}
+				r.markUnavailable("stars", err)
+				skipped = append(skipped, r)
+				continue
+			}
+			stars[name] = repo.Stars
+		}
+		ranked = append(ranked, rankedIssue{Issue: issue, Repo: name, Stars: stars[name]})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Stars != ranked[j].Stars {
+			return ranked[i].Stars > ranked[j].Stars
+		}
+		return ranked[i].Issue.Comments < ranked[j].Issue.Comments
+	})
+	return ranked, skipped, nil
+}
+
+func writeRankedIssues(w io.Writer, ranked []rankedIssue) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, tr("ESTRELAS\tREPOSITÓRIO\tISSUE\tCOMENTÁRIOS\tTÍTULO\tURL"))
+	for _, r := range ranked {
+		fmt.Fprintf(tw, "%d\t%s\t#%d\t%d\t%s\t%s\n", r.Stars, r.Repo, r.Issue.Number, r.Issue.Comments, r.Issue.Title, r.Issue.URL)
+	}
+	return tw.Flush()
+}
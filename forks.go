@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Ordenações aceitas por `forks -sort`.
+const (
+	forkSortStars  = "stars"
+	forkSortPushed = "pushed"
+)
+
+// ListForks lista até limit forks de fullName, dos mais estrelados para
+// os menos.
+func (c *Client) ListForks(ctx context.Context, fullName string, limit int) ([]Repository, error) {
+	return listAll[Repository](ctx, c, repoPath(fullName, "/forks"), url.Values{"sort": {"stargazers"}}, limit, nil)
+}
+
+// runForks implementa `forks owner/repo`: lista os forks de um projeto
+// para achar continuações mantidas de projetos abandonados.
+func runForks(args []string) error {
+	fs := newFlagSet("forks")
+	sortBy := fs.String("sort", forkSortStars, "ordenação: stars ou pushed (push mais recente primeiro)")
+	limit := fs.Int("limit", 100, "máximo de forks analisados (os mais estrelados)")
+	newer := fs.Bool("newer", false, "mostra só forks com push depois do último push do original")
+	format := fs.String("format", formatTable, "formato de saída: text, table, csv ou json")
+	fieldsSpec := fs.String("fields", "full_name,stars,open_issues,pushed_at,url", "colunas para table/csv/json, separadas por vírgula")
+	cf := addClientFlags(fs)
+
+	// O repositório pode vir antes ou depois das flags.
+	var repo string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		repo, args = args[0], args[1:]
+	}
+	fs.Parse(args)
+	if repo == "" && fs.NArg() > 0 {
+		repo = fs.Arg(0)
+	}
+	if !strings.Contains(repo, "/") {
+		return invalid(errors.New(tr("uso: forks owner/repo [-sort stars|pushed] [-newer]")))
+	}
+	if *sortBy != forkSortStars && *sortBy != forkSortPushed {
+		return invalid(fmt.Errorf(tr("-sort aceita stars ou pushed, recebido %q"), *sortBy))
+	}
+	if *limit < 1 {
+		return invalid(errors.New(tr("-limit deve ser positivo")))
+	}
+	fields, err := parseFields(*fieldsSpec)
+	if err != nil {
+		return err
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := cf.context()
+	defer cancel()
+
+	parent, err := client.GetRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	forks, err := client.ListForks(ctx, parent.FullName, *limit)
+	if err != nil {
+		return err
+	}
+
+	if *newer {
+		kept := forks[:0]
+		for _, f := range forks {
+			if f.PushedAt.After(parent.PushedAt) {
+				kept = append(kept, f)
+			}
+		}
+		forks = kept
+	}
+	if *sortBy == forkSortPushed {
+		sort.SliceStable(forks, func(a, b int) bool {
+			return forks[a].PushedAt.After(forks[b].PushedAt)
+		})
+	}
+
+	if *format == formatText || *format == formatTable {
+		fmt.Printf(tr("Original: %s (⭐ %d, %d forks, último push em %s)\n\n"),
+			parent.FullName, parent.Stars, parent.Forks, parent.PushedAt.Format("2006-01-02"))
+	}
+	return writeResults(os.Stdout, *format, &SearchResult{TotalCount: parent.Forks, Items: forks}, fields)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// Gist mapeia um gist. Na listagem os arquivos vêm sem Content; ele só
+// é preenchido ao buscar o gist individualmente.
+type Gist struct {
+	ID          string              `json:"id"`
+	Description string              `json:"description"`
+	URL         string              `json:"html_url"`
+	Public      bool                `json:"public"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Files       map[string]GistFile `json:"files"`
+}
+
+// GistFile mapeia um arquivo de um gist.
+type GistFile struct {
+	Filename  string `json:"filename"`
+	Language  string `json:"language"`
+	Size      int    `json:"size"`
+	RawURL    string `json:"raw_url"`
+	Truncated bool   `json:"truncated"`
+	Content   string `json:"content"`
+}
+
+// ListGists lista até limit gists públicos de um usuário, da mais
+// recente para a mais antiga.
+func (c *Client) ListGists(ctx context.Context, login string, limit int) ([]Gist, error) {
+	return listAll[Gist](ctx, c, "/users/"+url.PathEscape(login)+"/gists", nil, limit, nil)
+}
+
+// GetGist busca um gist com o conteúdo dos arquivos. Arquivos grandes
+// vêm truncados pela API; nesse caso o conteúdo completo é lido do raw_url.
+func (c *Client) GetGist(ctx context.Context, id string) (*Gist, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/gists/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	var gist Gist
+	if _, err := c.do(req, &gist); err != nil {
+		return nil, err
+	}
+
+	for name, f := range gist.Files {
+		if !f.Truncated {
+			continue
+		}
+		content, err := c.fetchRaw(ctx, f.RawURL)
+		if err != nil {
+			return nil, fmt.Errorf(tr("arquivo %s: %w"), name, err)
+		}
+		f.Content, f.Truncated = content, false
+		gist.Files[name] = f
+	}
+	return &gist, nil
+}
+
+// fetchRaw baixa um conteúdo bruto (fora da API, ex: gist.githubusercontent.com).
+func (c *Client) fetchRaw(ctx context.Context, rawURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", fmt.Errorf(tr("falha ao criar requisição: %w"), err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf(tr("falha ao executar requisição: %w"), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf(tr("download retornou status não-OK: %s"), resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf(tr("falha ao ler corpo da resposta: %w"), err)
+	}
+	return string(body), nil
+}
+
+// runGists implementa `gists list <login>` e `gists get <id>`.
+func runGists(args []string) error {
+	usage := errors.New(tr("uso: gists list <login> | gists get <id>"))
+	if len(args) < 2 {
+		return usage
+	}
+	action, target := args[0], args[1]
+
+	fs := newFlagSet("gists " + action)
+	limit := fs.Int("limit", 30, "máximo de gists listados")
+	cf := addClientFlags(fs)
+	fs.Parse(args[2:])
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := cf.context()
+	defer cancel()
+
+	switch action {
+	case "list":
+		gists, err := client.ListGists(ctx, target, *limit)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, tr("ID\tATUALIZADO\tARQUIVOS\tDESCRIÇÃO"))
+		for _, g := range gists {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", g.ID, g.UpdatedAt.Format("2006-01-02"), len(g.Files), g.Description)
+		}
+		return tw.Flush()
+	case "get":
+		gist, err := client.GetGist(ctx, target)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n%s\n\n", gist.Description, gist.URL)
+
+		// Ordem estável: o JSON devolve os arquivos como objeto.
+		names := make([]string, 0, len(gist.Files))
+		for name := range gist.Files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			f := gist.Files[name]
+			fmt.Printf("==> %s (%s, %d bytes) <==\n%s\n\n", f.Filename, f.Language, f.Size, f.Content)
+		}
+		return nil
+	}
+	return usage
+}
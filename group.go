@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+// ownerGroup agrega os repositórios de um mesmo dono.
+type ownerGroup struct {
+	Owner string `json:"owner"`
+	Type  string `json:"type"`
+	Repos int    `json:"repos"`
+	Stars int    `json:"stars"`
+	Forks int    `json:"forks"`
+}
+
+// groupByOwner soma estrelas e forks por dono, do maior total de
+// estrelas para o menor.
+func groupByOwner(repos []Repository) []ownerGroup {
+	index := map[string]int{}
+	var groups []ownerGroup
+	for _, r := range repos {
+		i, ok := index[r.Owner.Login]
+		if !ok {
+			i = len(groups)
+			index[r.Owner.Login] = i
+			groups = append(groups, ownerGroup{Owner: r.Owner.Login, Type: r.Owner.Type})
+		}
+		groups[i].Repos++
+		groups[i].Stars += r.Stars
+		groups[i].Forks += r.Forks
+	}
+
+	sort.SliceStable(groups, func(a, b int) bool {
+		return groups[a].Stars > groups[b].Stars
+	})
+	return groups
+}
+
+// writeGroups escreve o agrupamento. text e table usam o mesmo layout
+// tabular, já que não há campos a escolher.
+func writeGroups(w io.Writer, format string, groups []ownerGroup) error {
+	switch format {
+	case formatText, formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, tr("DONO\tTIPO\tREPOSITÓRIOS\tESTRELAS\tFORKS"))
+		for _, g := range groups {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\n", g.Owner, g.Type, g.Repos, g.Stars, g.Forks)
+		}
+		return tw.Flush()
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"owner", "type", "repos", "stars", "forks"})
+		for _, g := range groups {
+			cw.Write([]string{g.Owner, g.Type, strconv.Itoa(g.Repos), strconv.Itoa(g.Stars), strconv.Itoa(g.Forks)})
+		}
+		cw.Flush()
+		return cw.Error()
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(groups); err != nil {
+			return fmt.Errorf(tr("falha ao codificar JSON: %w"), err)
+		}
+		return nil
+	}
+	return fmt.Errorf(tr("formato desconhecido: %q (use text, table, csv ou json)"), format)
+}
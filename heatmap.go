@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// O GitHub calcula as estatísticas de commits sob demanda: a primeira
+// chamada devolve 202 Accepted enquanto o cálculo roda em segundo plano, e
+// as seguintes trazem o resultado. statsRetries e statsRetryDelay definem
+// quantas vezes tentar de novo e a espera inicial, que dobra a cada
+// tentativa.
+const (
+	statsRetries    = 4
+	statsRetryDelay = 2 * time.Second
+)
+
+// enrichHeatmap busca os commits por semana do último ano (52 semanas, da
+// mais antiga para a mais recente) em /stats/commit_activity. Repositórios
+// vazios (204) ficam com todas as semanas zeradas.
+func enrichHeatmap(ctx context.Context, c *Client, r *Repository) error {
+	delay := statsRetryDelay
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, http.MethodGet, repoPath(r.FullName, "/stats/commit_activity"), nil)
+		if err != nil {
+			return err
+		}
+		resp, body, err := c.send(req)
+		if err != nil {
+			return err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusAccepted:
+			if attempt == statsRetries {
+				return errors.New(tr("estatísticas de commits ainda em cálculo pelo GitHub; tente novamente em instantes"))
+			}
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+			delay *= 2
+			continue
+		case http.StatusNoContent:
+			r.CommitActivity = make([]int, 52)
+			return nil
+		}
+
+		var weeks []struct {
+			Total int `json:"total"`
+		}
+		if err := json.Unmarshal(body, &weeks); err != nil {
+			return fmt.Errorf(tr("falha ao decodificar JSON: %w"), err)
+		}
+		r.CommitActivity = make([]int, len(weeks))
+		for i, w := range weeks {
+			r.CommitActivity[i] = w.Total
+		}
+		return nil
+	}
+}
+
+// heatmapSummary desenha a atividade de commits como uma sparkline de um
+// caractere por semana; vazio quando o enriquecimento não foi pedido.
+func heatmapSummary(r Repository) string {
+	if r.unavailable("heatmap") {
+		return unavailableValue
+	}
+	if len(r.CommitActivity) == 0 {
+		return ""
+	}
+	total := 0
+	for _, n := range r.CommitActivity {
+		total += n
+	}
+	weeks := len(r.CommitActivity)
+	line := strings.Repeat("▁", weeks) // sparkline centraliza séries constantes
+	if total > 0 {
+		line = sparkline(r.CommitActivity, weeks, styleBlocks)
+	}
+	return fmt.Sprintf(tr("%s %d commits em %d semanas"), line, total, weeks)
+}
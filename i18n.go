@@ -0,0 +1,694 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Idiomas aceitos por -lang.
+const (
+	langPT = "pt"
+	langEN = "en"
+)
+
+// lang é o idioma das mensagens. O texto-fonte do programa é em
+// português; em inglês, cada mensagem é trocada pela sua tradução em
+// messagesEN.
+var lang = detectLang()
+
+// detectLang escolhe o idioma pelas variáveis de locale (LC_ALL,
+// LC_MESSAGES, LANG, nessa ordem de precedência). Locale português, "C",
+// "POSIX" ou nenhum mantêm o português; qualquer outro usa inglês.
+func detectLang() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := strings.ToLower(os.Getenv(name))
+		if v == "" {
+			continue
+		}
+		if v == "c" || v == "posix" || strings.HasPrefix(v, "c.") || strings.HasPrefix(v, langPT) {
+			return langPT
+		}
+		return langEN
+	}
+	return langPT
+}
+
+// setLang aplica o valor de -lang.
+func setLang(value string) error {
+	switch v := strings.ToLower(value); v {
+	case langPT, langEN:
+		lang = v
+		return nil
+	}
+	return fmt.Errorf(tr("idioma desconhecido em -lang: %q (use pt ou en)"), value)
+}
+
+// globalFlags são as flags que valem para todos os subcomandos e podem vir
+// antes dele.
+var globalFlags = map[string]func(string) error{
+	"lang":         setLang,
+	"error-format": setErrorFormat,
+}
+
+// langFromArgs consome as flags globais (-lang, -error-format) no início
+// dos argumentos, antes do subcomando (ex: `-lang en forks owner/repo`), e
+// devolve o restante.
+func langFromArgs(args []string) ([]string, error) {
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[0], "-"), "=")
+		set, ok := globalFlags[name]
+		if !ok {
+			break
+		}
+		args = args[1:]
+		if !hasValue {
+			if len(args) == 0 {
+				return nil, invalid(fmt.Errorf(tr("a flag -%s precisa de um valor"), name))
+			}
+			value, args = args[0], args[1:]
+		}
+		if err := set(value); err != nil {
+			return nil, invalid(err)
+		}
+	}
+	return args, nil
+}
+
+// tr traduz uma mensagem do programa para o idioma atual. Mensagens sem
+// tradução saem como estão.
+func tr(msg string) string {
+	if lang == langEN {
+		if t, ok := messagesEN[msg]; ok {
+			return t
+		}
+	}
+	return msg
+}
+
+// trError é um erro sentinela cuja mensagem é traduzida ao ser exibida, e
+// não na inicialização do pacote, quando -lang ainda não foi lido. Como é
+// comparável, errors.Is continua funcionando.
+type trError string
+
+func (e trError) Error() string { return tr(string(e)) }
+
+// newFlagSet cria o FlagSet de um subcomando com a ajuda traduzida e a
+// flag -lang.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Func("lang", "idioma das mensagens: pt ou en (padrão: conforme LANG)", setLang)
+	fs.Func("error-format", "formato dos erros em stderr: text ou json", setErrorFormat)
+	localizeUsage(fs)
+	return fs
+}
+
+// localizeUsage faz a ajuda de fs (-h) sair no idioma atual. As
+// descrições das flags são traduzidas só na hora de imprimir, pois o
+// idioma pode vir de uma flag ainda não lida quando elas são definidas.
+func localizeUsage(fs *flag.FlagSet) {
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), tr("Uso de %s:\n"), fs.Name())
+		fs.VisitAll(func(f *flag.Flag) { f.Usage = tr(f.Usage) })
+		fs.PrintDefaults()
+	}
+}
+
+// fieldLabels são os cabeçalhos das colunas de -format table em
+// português; em inglês, o cabeçalho é o próprio nome do campo.
+var fieldLabels = map[string]string{
+	"name":            "NOME",
+	"full_name":       "REPOSITÓRIO",
+	"owner":           "DONO",
+	"owner_type":      "TIPO DO DONO",
+	"owner_avatar":    "AVATAR",
+	"description":     "DESCRIÇÃO",
+	"url":             "URL",
+	"language":        "LINGUAGEM",
+	"stars":           "ESTRELAS",
+	"forks":           "FORKS",
+	"watchers":        "OBSERVADORES",
+	"open_issues":     "ISSUES ABERTAS",
+	"size":            "TAMANHO",
+	"default_branch":  "BRANCH PADRÃO",
+	"created_at":      "CRIADO EM",
+	"updated_at":      "ATUALIZADO EM",
+	"pushed_at":       "ÚLTIMO PUSH",
+	"topics":          "TÓPICOS",
+	"archived":        "ARQUIVADO",
+	"score":           "RELEVÂNCIA",
+	"last_commit_at":  "ÚLTIMO COMMIT",
+	"health":          "SAÚDE",
+	"velocity":        "VELOCIDADE",
+	"ci":              "CI",
+	"security":        "SEGURANÇA",
+	"commit_activity": "COMMITS (52 SEMANAS)",
+	"languages":       "LINGUAGENS",
+	"release":         "ÚLTIMA RELEASE",
+	"starred_at":      "ESTRELA EM",
+	"notes":           "NOTAS",
+}
+
+// fieldLabel é o cabeçalho da coluna name em -format table.
+func fieldLabel(name string) string {
+	if label, ok := fieldLabels[name]; ok && lang == langPT {
+		return label
+	}
+	return strings.ToUpper(name)
+}
+
+// messagesEN traduz para o inglês as mensagens do programa, indexadas
+// pelo texto original.
+var messagesEN = map[string]string{
+	// apiversion.go
+	"versão da API inválida: %q (use o formato AAAA-MM-DD)": "invalid API version: %q (use the YYYY-MM-DD format)",
+	"padrão do GitHub": "GitHub default",
+	"aviso: o GitHub marcou %s (API versão %s) como obsoleto": "warning: GitHub marked %s (API version %s) as deprecated",
+	" desde %s":                      " since %s",
+	"; desativação prevista para %s": "; scheduled to be shut down on %s",
+
+	// auth.go
+	"informe o Client ID do OAuth App com -client-id ou GHSEARCH_CLIENT_ID":                       "provide the OAuth App Client ID with -client-id or GHSEARCH_CLIENT_ID",
+	"Abra %s e digite o código: %s\n":                                                             "Open %s and enter the code: %s\n",
+	"Aguardando autorização...":                                                                   "Waiting for authorization...",
+	"Login concluído (escopos: %q). Token salvo em: %s\n":                                         "Login complete (scopes: %q). Token saved to: %s\n",
+	"GitHub não devolveu um device_code; verifique se o device flow está habilitado no OAuth App": "GitHub returned no device_code; check that device flow is enabled for the OAuth App",
+	"login recusado pelo GitHub: %s (%s)":                                                         "login rejected by GitHub: %s (%s)",
+	"o código de autorização expirou; execute login novamente":                                    "the authorization code expired; run login again",
+	"falha ao criar requisição: %w":                                                               "failed to create request: %w",
+	"falha ao executar requisição: %w":                                                            "failed to execute request: %w",
+	"GitHub retornou status não-OK: %s":                                                           "GitHub returned non-OK status: %s",
+	"falha ao decodificar JSON: %w":                                                               "failed to decode JSON: %w",
+	"Client ID do OAuth App (ou GHSEARCH_CLIENT_ID)":                                              "OAuth App Client ID (or GHSEARCH_CLIENT_ID)",
+	"escopos solicitados, separados por espaço":                                                   "requested scopes, space separated",
+
+	// awesome.go
+	"Outros": "Other",
+	"> Lista gerada a partir da busca `%s` em %s.\n\n": "> List generated from the search `%s` on %s.\n\n",
+	"## Conteúdo\n\n":         "## Contents\n\n",
+	"estrelas":                "stars",
+	"%d repositórios em %s\n": "%d repositories in %s\n",
+	"título da lista (padrão: derivado do primeiro topic: da query)":               "list title (default: derived from the first topic: in the query)",
+	"arquivo de saída (padrão: saída padrão)":                                      "output file (default: standard output)",
+	"similaridade mínima (0..1) para dois repositórios ficarem na mesma categoria": "minimum similarity (0..1) for two repositories to share a category",
+
+	// batch.go
+	"falha ao abrir arquivo de queries: %w":                  "failed to open queries file: %w",
+	"falha ao ler queries: %w":                               "failed to read queries: %w",
+	"o arquivo de queries está vazio":                        "the queries file is empty",
+	"falha ao criar diretório de saída: %w":                  "failed to create output directory: %w",
+	"CSV em lote precisa de -out-dir (um arquivo por query)": "batch CSV requires -out-dir (one file per query)",
+	"=== combinado ===":                                      "=== combined ===",
+	"falha ao criar %s: %w":                                  "failed to create %s: %w",
+	"lote":                                                   "batch",
+
+	// body.go
+	"resposta excede o limite de %d bytes": "response exceeds the %d byte limit",
+	"falha ao descomprimir resposta: %w":   "failed to decompress response: %w",
+
+	// cache.go
+	"falha ao localizar diretório de cache: %w":         "failed to locate cache directory: %w",
+	"falha ao criar diretório de cache: %w":             "failed to create cache directory: %w",
+	"falha ao ler corpo da resposta: %w":                "failed to read response body: %w",
+	"uso: cache stats | cache clear":                    "usage: cache stats | cache clear",
+	"Diretório:        %s\n":                            "Directory:        %s\n",
+	"Entradas:         %d\n":                            "Entries:          %d\n",
+	"Tamanho em disco: %.1f KB\n":                       "Size on disk:     %.1f KB\n",
+	"Acertos/erros:    %d/%d (taxa de acerto %.1f%%)\n": "Hits/misses:      %d/%d (hit rate %.1f%%)\n",
+	"falha ao limpar cache: %w":                         "failed to clear cache: %w",
+	"%d entradas removidas de %s\n":                     "%d entries removed from %s\n",
+	"falha ao ler cache: %w":                            "failed to read cache: %w",
+
+	// chart.go
+	"uso: chart owner/repo [-db snapshots.db]":            "usage: chart owner/repo [-db snapshots.db]",
+	"-width deve ser positivo":                            "-width must be positive",
+	"-style aceita blocks, braille ou ascii, recebido %q": "-style accepts blocks, braille or ascii, got %q",
+	"nenhum snapshot de %s em %s":                         "no snapshots of %s in %s",
+	"falha ao abrir banco de snapshots: %w":               "failed to open snapshot database: %w",
+	"%s — %d snapshots de %s a %s\n":                      "%s — %d snapshots from %s to %s\n",
+	"mín %d  máx %d  variação %+d\n":                      "min %d  max %d  change %+d\n",
+	"banco SQLite com os snapshots gravados pelo daemon":  "SQLite database with the snapshots written by the daemon",
+	"largura máxima do gráfico, em caracteres":            "maximum chart width, in characters",
+	"estilo do gráfico: blocks, braille ou ascii":         "chart style: blocks, braille or ascii",
+
+	// client.go
+	"API do GitHub retornou status não-OK: %s (%s)": "GitHub API returned non-OK status: %s (%s)",
+	"API do GitHub retornou status não-OK: %s":      "GitHub API returned non-OK status: %s",
+
+	// cluster.go
+	"## Cluster %d — %d repositórios: %s\n":                   "## Cluster %d — %d repositories: %s\n",
+	"(sem similares)":                                         "(no similar repositories)",
+	"formato desconhecido: %q (use text, table, csv ou json)": "unknown format: %q (use text, table, csv or json)",
+
+	// compare.go
+	"uso: compare-languages go,rust,zig [-q \"topic:web-framework\"]":       "usage: compare-languages go,rust,zig [-q \"topic:web-framework\"]",
+	"-sample deve estar entre 1 e %d":                                       "-sample must be between 1 and %d",
+	"LINGUAGEM\tTOTAL\tMEDIANA DE ESTRELAS\tPRINCIPAIS":                     "LANGUAGE\tTOTAL\tMEDIAN STARS\tTOP",
+	"falha ao codificar JSON: %w":                                           "failed to encode JSON: %w",
+	"formato desconhecido: %q (use table ou json)":                          "unknown format: %q (use table or json)",
+	"qualificadores comuns a todas as linguagens (ex: topic:web-framework)": "qualifiers shared by all languages (e.g. topic:web-framework)",
+	"repositórios buscados por linguagem para calcular a mediana":           "repositories fetched per language to compute the median",
+	"quantos repositórios mostrar por linguagem":                            "how many repositories to show per language",
+	"formato de saída: table ou json":                                       "output format: table or json",
+
+	// cron.go
+	"cron: intervalo inválido em %q":                 "cron: invalid interval in %q",
+	"cron: esperados 5 campos em %q, encontrados %d": "cron: expected 5 fields in %q, found %d",
+	"cron: campo %s: %w":                             "cron: field %s: %w",
+	"passo inválido %q":                              "invalid step %q",
+	"valor inválido %q":                              "invalid value %q",
+	"%q fora do intervalo %d-%d":                     "%q out of range %d-%d",
+	"minuto":                                         "minute",
+	"hora":                                           "hour",
+	"dia do mês":                                     "day of month",
+	"mês":                                            "month",
+	"dia da semana":                                  "day of week",
+
+	// daemon.go
+	"tipo de sink desconhecido %q":                                               "unknown sink type %q",
+	"falha ao ler configuração do daemon: %w":                                    "failed to read daemon configuration: %w",
+	"configuração do daemon inválida: %w":                                        "invalid daemon configuration: %w",
+	"configuração do daemon sem jobs":                                            "daemon configuration has no jobs",
+	"job %s: nenhum sink configurado":                                            "job %s: no sinks configured",
+	"daemon: %d jobs carregados de %s":                                           "daemon: %d jobs loaded from %s",
+	"daemon: job %s nunca mais será executado":                                   "daemon: job %s will never run again",
+	"daemon: próxima execução de %s às %s":                                       "daemon: next run of %s at %s",
+	"daemon: job %s falhou: %v":                                                  "daemon: job %s failed: %v",
+	"daemon: job %s desde a última execução: %s":                                 "daemon: job %s since the last run: %s",
+	"daemon: job %s coletou %d repositórios":                                     "daemon: job %s collected %d repositories",
+	"arquivo de configuração (padrão: daemon.json no diretório de configuração)": "configuration file (default: daemon.json in the configuration directory)",
+	"executa cada job uma vez, imediatamente, e sai":                             "runs each job once, immediately, and exits",
+	"prazo para os jobs em andamento terminarem depois de SIGINT/SIGTERM":        "time allowed for running jobs to finish after SIGINT/SIGTERM",
+	"daemon: prazo de encerramento esgotado; jobs em andamento interrompidos":    "daemon: shutdown grace period expired; running jobs interrupted",
+	"daemon: encerrado":                                                          "daemon: stopped",
+
+	// demo.go
+	"dados de exemplo corrompidos em %s: %w": "corrupted sample data in %s: %w",
+
+	// dependents.go
+	"uso: dependents module/path (ex: dependents github.com/spf13/cobra)":                    "usage: dependents module/path (e.g. dependents github.com/spf13/cobra)",
+	"-limit deve estar entre 1 e %d":                                                         "-limit must be between 1 and %d",
+	"a busca de código do GitHub exige autenticação: configure GITHUB_TOKEN ou rode `login`": "GitHub code search requires authentication: set GITHUB_TOKEN or run `login`",
+	"Buscando %d/%d: %s": "Fetching %d/%d: %s",
+	"%d go.mod citam %s; %d repositórios distintos entre os %d analisados.\n\n": "%d go.mod files mention %s; %d distinct repositories among the %d analyzed.\n\n",
+	"máximo de go.mod analisados pela busca de código (máx. 1000)":              "maximum go.mod files analyzed by code search (max. 1000)",
+	"quantos dependentes mostrar":                                               "how many dependents to show",
+	"inclui forks entre os dependentes":                                         "includes forks among the dependents",
+	"formato de saída: text, table, csv ou json":                                "output format: text, table, csv or json",
+	"colunas para table/csv/json, separadas por vírgula":                        "columns for table/csv/json, comma separated",
+	"dependentes": "dependents",
+
+	// deps.go
+	"%d repositórios com go.mod analisados (%d sem go.mod na raiz).\n\n": "%d repositories with go.mod analyzed (%d without a root go.mod).\n\n",
+	"MÓDULO\tREPOSITÓRIOS\t%\tVERSÃO MAIS USADA\t":                       "MODULE\tREPOSITORIES\t%\tMOST USED VERSION\t",
+	"quantas dependências mostrar":                                       "how many dependencies to show",
+	"conta também as dependências marcadas // indirect":                  "also counts dependencies marked // indirect",
+
+	// diff.go
+	"%d novos, %d removidos, %d alterados": "%d added, %d removed, %d changed",
+	"falha ao ler %s: %w":                  "failed to read %s: %w",
+	"falha ao decodificar %s: %w":          "failed to decode %s: %w",
+	"%s está vazio":                        "%s is empty",
+	"falha ao decodificar resultado: %w":   "failed to decode result: %w",
+	"o arquivo não tem items; use um snapshot do daemon/-sink ou a saída de -format json": "the file has no items; use a daemon/-sink snapshot or -format json output",
+	"item %d sem full_name (inclua a coluna em -fields)":                                  "item %d has no full_name (include the column in -fields)",
+	"%d repositórios":       "%d repositories",
+	"%d repositórios em %s": "%d repositories at %s",
+	"De %s para %s: %s\n":   "From %s to %s: %s\n",
+	"\nNovos:\n":            "\nAdded:\n",
+	"\nRemovidos:\n":        "\nRemoved:\n",
+	"\nAlterados:\n":        "\nChanged:\n",
+	"  %s ⭐ %+d (%d → %d) · forks %+d · issues %+d · posição %d (%+d)\n": "  %s ⭐ %+d (%d → %d) · forks %+d · issues %+d · rank %d (%+d)\n",
+	"uso: diff snapshotA.json snapshotB.json":                            "usage: diff snapshotA.json snapshotB.json",
+	"-format aceita text ou json, recebido %q":                           "-format accepts text or json, got %q",
+	"formato de saída: text ou json":                                     "output format: text or json",
+
+	// enrich.go
+	"enriquecimento desconhecido em -enrich: %q (disponíveis: %s)": "unknown enrichment in -enrich: %q (available: %s)",
+	"enriquecimento": "enrichment",
+	"repositórios":   "repositories",
+	"quota esgotada": "quota exhausted",
+	"\n%d enriquecimento(s) indisponível(is):\n%s\n": "\n%d enrichment(s) unavailable:\n%s\n",
+	"tempo esgotado": "timed out",
+
+	// exitcode.go
+	"-error-format aceita text ou json, recebido %q": "-error-format accepts text or json, got %q",
+	"nenhum repositório encontrado para %q":          "no repositories found for %q",
+	"formato dos erros em stderr: text ou json":      "format of errors on stderr: text or json",
+
+	// export.go
+	"formato desconhecido: %q (use json, markdown ou both)": "unknown format: %q (use json, markdown or both)",
+	"Exportando %d/%d: %s":                              "Exporting %d/%d: %s",
+	"falha ao salvar %s: %w":                            "failed to save %s: %w",
+	"falha ao salvar índice: %w":                        "failed to save index: %w",
+	"%d repositórios exportados para %s\n":              "%d repositories exported to %s\n",
+	"tópicos: %w":                                       "topics: %w",
+	"# Catálogo: `%s`\n\n":                              "# Catalog: `%s`\n\n",
+	"- Estrelas: %d · Forks: %d · Issues abertas: %d\n": "- Stars: %d · Forks: %d · Open issues: %d\n",
+	"- Linguagem: %s\n":                                 "- Language: %s\n",
+	"nenhuma detectada":                                 "none detected",
+	"- Licença: %s\n":                                   "- License: %s\n",
+	"- Tópicos: %s\n":                                   "- Topics: %s\n",
+	"- Último push: %s\n":                               "- Last push: %s\n",
+	"_Sem README._\n":                                   "_No README._\n",
+	"diretório de saída":                                "output directory",
+	"formato dos arquivos: json, markdown ou both":      "file format: json, markdown or both",
+
+	// filter.go
+	"duração inválida %q (use por exemplo 90d, 6w, 1y)": "invalid duration %q (use e.g. 90d, 6w, 1y)",
+
+	// firstissues.go
+	"ESTRELAS\tREPOSITÓRIO\tISSUE\tCOMENTÁRIOS\tTÍTULO\tURL": "STARS\tREPO\tISSUE\tCOMMENTS\tTITLE\tURL",
+	"linguagem dos repositórios":                             "repository language",
+	"label que marca issues para iniciantes":                 "label that marks beginner issues",
+	"issues buscadas antes do ranking (máx. 1000)":           "issues fetched before ranking (max. 1000)",
+	"quantas issues mostrar":                                 "how many issues to show",
+	"ignora repositórios com menos estrelas":                 "ignores repositories with fewer stars",
+	"-top deve ser positivo":                                 "-top must be positive",
+
+	// forks.go
+	"página %d: %w": "page %d: %w",
+	"uso: forks owner/repo [-sort stars|pushed] [-newer]":        "usage: forks owner/repo [-sort stars|pushed] [-newer]",
+	"-sort aceita stars ou pushed, recebido %q":                  "-sort accepts stars or pushed, got %q",
+	"-limit deve ser positivo":                                   "-limit must be positive",
+	"Original: %s (⭐ %d, %d forks, último push em %s)\n\n":       "Original: %s (⭐ %d, %d forks, last push on %s)\n\n",
+	"ordenação: stars ou pushed (push mais recente primeiro)":    "sort: stars or pushed (most recent push first)",
+	"máximo de forks analisados (os mais estrelados)":            "maximum forks analyzed (the most starred)",
+	"mostra só forks com push depois do último push do original": "shows only forks pushed after the original's last push",
+
+	// gists.go
+	"arquivo %s: %w":                           "file %s: %w",
+	"download retornou status não-OK: %s":      "download returned non-OK status: %s",
+	"uso: gists list <login> | gists get <id>": "usage: gists list <login> | gists get <id>",
+	"ID\tATUALIZADO\tARQUIVOS\tDESCRIÇÃO":      "ID\tUPDATED\tFILES\tDESCRIPTION",
+	"máximo de gists listados":                 "maximum gists listed",
+
+	// group.go
+	"DONO\tTIPO\tREPOSITÓRIOS\tESTRELAS\tFORKS": "OWNER\tTYPE\tREPOS\tSTARS\tFORKS",
+
+	// heatmap.go
+	"estatísticas de commits ainda em cálculo pelo GitHub; tente novamente em instantes": "commit statistics are still being computed by GitHub; try again shortly",
+	"%s %d commits em %d semanas": "%s %d commits in %d weeks",
+
+	// lucky.go
+	"   ⭐ Estrelas:      %d · forks %d · issues abertas %d\n": "   ⭐ Stars:         %d · forks %d · open issues %d\n",
+	"   💬 Linguagem:     %s\n":                                "   💬 Language:      %s\n",
+	"   ⚖  Licença:       %s\n":                               "   ⚖  License:       %s\n",
+	"   🏷  Tópicos:       %s\n":                               "   🏷  Topics:        %s\n",
+	"   📅 Criado em:     %s · último push %s\n":               "   📅 Created:       %s · last push %s\n",
+	"   📝 Último commit: %s\n":                                "   📝 Last commit:   %s\n",
+	"   🛡  Segurança:     %s\n":                               "   🛡  Security:      %s\n",
+	"   📦 Última release: %s (%s) %s\n":                       "   📦 Latest release: %s (%s) %s\n",
+	"   📦 Última release: nenhuma\n":                          "   📦 Latest release: none\n",
+	"falha ao abrir o navegador: %w":                          "failed to open the browser: %w",
+	"   📝 Notas:         %s\n":                                "   📝 Notes:         %s\n",
+	"   💬 Linguagens:    %s\n":                                "   💬 Languages:     %s\n",
+	"   📦 Última release: %s\n":                               "   📦 Latest release: %s\n",
+
+	// main.go
+	"-group-by aceita apenas owner, recebido %q": "-group-by only accepts owner, got %q",
+	"ERRO: %v":                                               "ERROR: %v",
+	"-per-page deve estar entre 1 e %d":                      "-per-page must be between 1 and %d",
+	"-active-within deve ser positivo":                       "-active-within must be positive",
+	"use apenas um entre -group-by, -cluster e -topic-cloud": "use only one of -group-by, -cluster and -topic-cloud",
+	"-cluster-threshold deve estar entre 0 e 1":              "-cluster-threshold must be between 0 and 1",
+	"Buscando repositórios no GitHub...\nQuery: '%s', ordenação: '%s', direção: '%s'\n\n": "Searching GitHub repositories...\nQuery: '%s', Sort By: '%s', Order: '%s'\n\n",
+	"\naviso: query %q: %v\n":                                                                                     "\nwarning: query %q: %v\n",
+	"grava as respostas da API neste diretório":                                                                   "records API responses in this directory",
+	"responde a partir das gravações deste diretório, sem rede":                                                   "replies from the recordings in this directory, without network",
+	"User-Agent enviado à API":                                                                                    "User-Agent sent to the API",
+	"validade do cache de respostas (0 desliga o cache)":                                                          "response cache lifetime (0 disables the cache)",
+	"arquivo com tokens extras, um por linha, usados em rodízio (também GITHUB_TOKENS)":                           "file with extra tokens, one per line, used in rotation (also GITHUB_TOKENS)",
+	"inclui a quota anônima no rodízio de tokens":                                                                 "includes the anonymous quota in the token rotation",
+	"tamanho máximo de cada resposta da API, em MB (0 = sem limite)":                                              "maximum size of each API response, in MB (0 = no limit)",
+	"termo de busca (aceita qualificadores do GitHub)":                                                            "search term (accepts GitHub qualifiers)",
+	"direção da ordenação: asc ou desc":                                                                           "sort direction: asc or desc",
+	"total de resultados desejados (máx. 1000)":                                                                   "total results wanted (max. 1000)",
+	"resultados por página da API (1..100)":                                                                       "results per API page (1..100)",
+	"mantém só repositórios com push nesse período (ex: 90d)":                                                     "keeps only repositories pushed within this period (e.g. 90d)",
+	"mantém só repositórios atualizados nesse período":                                                            "keeps only repositories updated within this period",
+	"mantém só repositórios criados nesse período":                                                                "keeps only repositories created within this period",
+	"mantém só repositórios com todos estes tópicos, separados por vírgula":                                       "keeps only repositories with all of these topics, comma separated",
+	"descarta repositórios com qualquer um destes tópicos":                                                        "drops repositories with any of these topics",
+	"só projetos mantidos: push recente, não arquivados, commit confirmado na branch padrão":                      "only maintained projects: recent push, not archived, confirmed commit on the default branch",
+	"janela de atividade usada por -active":                                                                       "activity window used by -active",
+	"enriquecimentos por repositório, separados por vírgula: languages, release, ci, security, activity, heatmap": "per-repository enrichments, comma separated: languages, release, ci, security, activity, heatmap",
+	"atalho para incluir security em -enrich":                                                                     "shortcut to include security in -enrich",
+	"agrupa os resultados; valores aceitos: owner":                                                                "groups the results; accepted values: owner",
+	"mostra os tópicos mais frequentes no resultado em vez da lista":                                              "shows the most frequent topics in the result instead of the list",
+	"agrupa repositórios de descrição parecida":                                                                   "groups repositories with similar descriptions",
+	"similaridade mínima (0..1) para -cluster":                                                                    "minimum similarity (0..1) for -cluster",
+	"arquivo com uma query por linha (- para stdin)":                                                              "file with one query per line (- for stdin)",
+	"queries do lote executadas em paralelo":                                                                      "batch queries run in parallel",
+	"no modo lote, grava um arquivo por query e o combinado neste diretório":                                      "in batch mode, writes one file per query and the combined one to this directory",
+	"também entrega os resultados a um destino (repetível): stdout, file:, sqlite:, webhook:, s3://":              "also delivers the results to a destination (repeatable): stdout, file:, sqlite:, webhook:, s3://",
+	"reordena no cliente: stars, forks, velocity, health ou weighted":                                             "reorders client-side: stars, forks, velocity, health or weighted",
+	"pesos do -rank weighted, ex: stars=0.6,recency=0.4":                                                          "weights for -rank weighted, e.g. stars=0.6,recency=0.4",
+	"máximo de chamadas à API nesta execução (0 = apenas a quota)":                                                "maximum API calls in this run (0 = quota only)",
+	"quando o plano não couber: warn, prompt ou downscale":                                                        "when the plan does not fit: warn, prompt or downscale",
+	"falha se alguma página da busca falhar, em vez de mostrar os resultados parciais":                            "fails if any search page fails, instead of showing the partial results",
+	"não mostra o progresso no stderr":                                                                            "does not show progress on stderr",
+	"mostra a versão e sai":                                                                                       "shows the version and exits",
+	"-open só vale com -lucky":                                                                                    "-open only applies with -lucky",
+	"-lucky não combina com -queries-file, -group-by, -cluster ou -topic-cloud":                                   "-lucky does not combine with -queries-file, -group-by, -cluster or -topic-cloud",
+	"nenhum repositório encontrado":                                                                               "no repository found",
+	"Abrindo %s\n":                                                                                                "Opening %s\n",
+	"pega só o primeiro resultado e mostra o perfil detalhado dele":                                               "takes only the top result and shows its detailed profile",
+	"com -lucky, abre o repositório no navegador em vez de mostrar o perfil":                                      "with -lucky, opens the repository in the browser instead of showing the profile",
+	"versão da API REST enviada em X-GitHub-Api-Version (vazio = padrão do GitHub)":                               "REST API version sent in X-GitHub-Api-Version (empty = GitHub default)",
+	"validade do cache de resultados vazios (0 não os guarda)":                                                    "cache lifetime of empty results (0 does not store them)",
+	"validade do cache de queries rejeitadas com 422 (0 não as guarda)":                                           "cache lifetime of queries rejected with 422 (0 does not store them)",
+	"campo de ordenação: stars, forks, updated, best-match (relevância)... ou health (no cliente)":                "sort field: stars, forks, updated, best-match (relevance)... or health (client-side)",
+	"mantém só repositórios com relevância (score) pelo menos igual a este valor":                                 "keeps only repositories with relevance (score) at least this value",
+	"-min-score não pode ser negativo":                                                                            "-min-score cannot be negative",
+	"mantém só repositórios com até este tamanho em MB (0 = sem limite)":                                          "keeps only repositories up to this size in MB (0 = no limit)",
+	"-max-size-mb não pode ser negativo":                                                                          "-max-size-mb cannot be negative",
+	"marca com estrela os N primeiros resultados (exige token)":                                                   "stars the first N results (requires a token)",
+	"tira a estrela dos N primeiros resultados (exige token)":                                                     "unstars the first N results (requires a token)",
+	"-star-top e -unstar-top não podem ser negativos":                                                             "-star-top and -unstar-top cannot be negative",
+	"use -star-top ou -unstar-top, não os dois":                                                                   "use -star-top or -unstar-top, not both",
+	"-star-top e -unstar-top não combinam com -queries-file ou -lucky":                                            "-star-top and -unstar-top do not combine with -queries-file or -lucky",
+	"marcar estrelas exige autenticação: configure GITHUB_TOKEN ou rode `login`":                                  "starring requires authentication: set GITHUB_TOKEN or run `login`",
+	"aviso: notas indisponíveis: %v\n":                                                                            "warning: notes unavailable: %v\n",
+	"prazo da execução inteira; no daemon, de cada execução de job; no serve, de cada busca (0 = sem prazo)":      "deadline for the whole run; in daemon mode, for each job run; in serve mode, for each search (0 = none)",
+	"prazo de cada requisição à API, por tentativa (0 = sem prazo)":                                               "deadline for each API request, per attempt (0 = none)",
+	"prazo de cada página da busca, com esperas e repetições (0 = só -request-timeout)":                           "deadline for each search page, including waits and retries (0 = -request-timeout only)",
+	"prazo de cada enriquecimento por repositório (0 = só -request-timeout)":                                      "deadline for each per-repository enrichment (0 = -request-timeout only)",
+	"usa dados de exemplo embutidos, sem rede nem token (demonstrações, aulas, CI)":                               "uses embedded sample data, with no network or token (demos, teaching, CI)",
+	"use -demo sem -record e -replay":                                                                             "use -demo without -record and -replay",
+
+	// notes.go
+	"ID\tREPOSITÓRIO\tDATA\tTAGS\tNOTA": "ID\tREPOSITORY\tDATE\tTAGS\tNOTE",
+	"uso: note add owner/repo \"texto\" [-tag a,b] | note list [owner/repo] | note rm owner/repo [-id N]": "usage: note add owner/repo \"text\" [-tag a,b] | note list [owner/repo] | note rm owner/repo [-id N]",
+	"tags da nota, separadas por vírgula":                                    "note tags, comma-separated",
+	"com rm, apaga só a nota com este id (padrão: todas do repositório)":     "with rm, deletes only the note with this id (default: all of the repository)",
+	"banco SQLite das notas (padrão: notes.db no diretório de configuração)": "SQLite notes database (default: notes.db in the configuration directory)",
+	"Nota adicionada a %s\n":                                                 "Note added to %s\n",
+	"Nenhuma nota ainda.":                                                    "No notes yet.",
+
+	// options.go
+	"URL base inválida: %q":                         "invalid base URL: %q",
+	"o timeout não pode ser negativo":               "the timeout cannot be negative",
+	"retries e backoff não podem ser negativos":     "retries and backoff cannot be negative",
+	"as validades do cache não podem ser negativas": "cache lifetimes cannot be negative",
+
+	// output.go
+	"campo desconhecido em -fields: %q (disponíveis: %s)":       "unknown field in -fields: %q (available: %s)",
+	"-fields não pode ser vazio":                                "-fields cannot be empty",
+	"Encontrados %d repositórios. Mostrando os %d primeiros:\n": "Found %d repositories. Showing the first %d:\n",
+	"   ⭐ Estrelas: %d\n":                                       "   ⭐ Stars:    %d\n",
+	"   🛡  Segurança: %s\n":                                     "   🛡  Security: %s\n",
+	"falha ao escrever CSV: %w":                                 "failed to write CSV: %w",
+	"   📈 Commits:   %s\n":                                      "   📈 Commits:   %s\n",
+	"   📝 Notas:     %s\n":                                      "   📝 Notes:     %s\n",
+	"   💬 Linguagens: %s\n":                                     "   💬 Languages:  %s\n",
+
+	// planner.go
+	"a execução prevê %d chamadas (%d de busca, %d de enriquecimento); quota restante: %d de busca, %d core": "the run needs %d calls (%d search, %d enrichment); remaining quota: %d search, %d core",
+	"; orçamento: %d":                    "; budget: %d",
+	"AVISO: %s":                          "WARNING: %s",
+	"%s.\nContinuar mesmo assim? [s/N] ": "%s.\nContinue anyway? [y/N] ",
+	"execução cancelada pelo usuário":    "run cancelled by the user",
+	"modo de orçamento desconhecido: %q (use warn, prompt ou downscale)": "unknown budget mode: %q (use warn, prompt or downscale)",
+	"nem o plano mínimo cabe no orçamento: %s":                           "not even the minimal plan fits the budget: %s",
+	"AVISO: %s; reduzindo para -limit %d e -enrich %d":                   "WARNING: %s; reducing to -limit %d and -enrich %d",
+
+	// pool.go
+	"token %s do pool: %w":                 "pool token %s: %w",
+	"anônimo":                              "anonymous",
+	"falha ao abrir arquivo de tokens: %w": "failed to open tokens file: %w",
+	"falha ao ler arquivo de tokens: %w":   "failed to read tokens file: %w",
+
+	// preflight.go
+	"token inválido ou sem acesso a /user: %w": "invalid token or no access to /user: %w",
+	"uso: auth status":                         "usage: auth status",
+	"Autenticado como: %s\n":                   "Authenticated as: %s\n",
+	"Escopos:          %v\n":                   "Scopes:           %v\n",
+	"Escopos:          (nenhum informado; token fine-grained ou sem escopos)": "Scopes:           (none reported; fine-grained token or no scopes)",
+	"Sem token: as chamadas serão anônimas.":                                  "No token: calls will be anonymous.",
+	"Quota core:       %d/%d (renova às %s)\n":                                "Core quota:       %d/%d (resets at %s)\n",
+	"Quota search:     %d/%d (renova às %s)\n":                                "Search quota:     %d/%d (resets at %s)\n",
+
+	// progress.go
+	"%d itens":               "%d items",
+	"aguardando limite por ": "waiting on rate limit for ",
+	"busca":                  "search",
+	"páginas":                "pages",
+
+	// query.go
+	"aspas sem par na query": "unbalanced quotes in query",
+	"query inválida %q: %w":  "invalid query %q: %w",
+	"query vazia":            "empty query",
+	"query inválida %q: qualificador desconhecido %q (para buscar o texto literal, use aspas: \"%s\")": "invalid query %q: unknown qualifier %q (to search for the literal text, use quotes: \"%s\")",
+	"query inválida %q: qualificador %q sem valor":                                                     "invalid query %q: qualifier %q has no value",
+	"query inválida %q: %d operadores AND/OR/NOT (o GitHub aceita até %d)":                             "invalid query %q: %d AND/OR/NOT operators (GitHub accepts up to %d)",
+	"query inválida: o texto tem %d caracteres (o GitHub aceita até %d, sem contar qualificadores)":    "invalid query: the text has %d characters (GitHub accepts up to %d, not counting qualifiers)",
+
+	// rank.go
+	"critério desconhecido em -rank-weights: %q (disponíveis: %s)":          "unknown criterion in -rank-weights: %q (available: %s)",
+	"peso inválido em -rank-weights: %q (use critério=peso, ex: stars=0.6)": "invalid weight in -rank-weights: %q (use criterion=weight, e.g. stars=0.6)",
+	"-rank-weights vazio": "empty -rank-weights",
+	"-rank-weights só combina com -rank weighted, recebido %q": "-rank-weights only combines with -rank weighted, got %q",
+	"-rank weighted exige -rank-weights":                       "-rank weighted requires -rank-weights",
+	"-rank desconhecido: %q (disponíveis: %s, weighted)":       "unknown -rank: %q (available: %s, weighted)",
+
+	// recorder.go
+	"falha ao codificar gravação: %w":          "failed to encode recording: %w",
+	"falha ao salvar gravação: %w":             "failed to save recording: %w",
+	"nenhuma gravação para %s %s":              "no recording for %s %s",
+	"falha ao ler gravação: %w":                "failed to read recording: %w",
+	"gravação corrompida em %s: %w":            "corrupted recording in %s: %w",
+	"use -record ou -replay, não os dois":      "use -record or -replay, not both",
+	"falha ao criar diretório de gravação: %w": "failed to create recording directory: %w",
+
+	// reviewload.go
+	"REPOSITÓRIO\tESTRELAS\tPRS ABERTOS\tIDADE MÉDIA (DIAS)\tMAIS ANTIGO": "REPOSITORY\tSTARS\tOPEN PRS\tAVERAGE AGE (DAYS)\tOLDEST",
+	"máximo de PRs analisados por repositório (os mais antigos primeiro)": "maximum PRs analyzed per repository (oldest first)",
+	"ordena por open (PRs abertos) ou age (idade média)":                  "sorts by open (open PRs) or age (average age)",
+	"conta também os PRs em rascunho":                                     "also counts draft PRs",
+	"formato de saída: table, csv ou json":                                "output format: table, csv or json",
+	"-by aceita open ou age, recebido %q":                                 "-by accepts open or age, got %q",
+	"carga de revisão":                                                    "review load",
+
+	// s3.go
+	"sink S3 inválido %q (use s3://<bucket>/<prefixo>)":        "invalid S3 sink %q (use s3://<bucket>/<prefix>)",
+	"sink S3 requer AWS_ACCESS_KEY_ID e AWS_SECRET_ACCESS_KEY": "S3 sink requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY",
+	"falha ao codificar snapshot: %w":                          "failed to encode snapshot: %w",
+	"S3 retornou status não-OK: %s":                            "S3 returned non-OK status: %s",
+
+	// sample.go
+	"amostra":                     "sample",
+	"-page deve ser pelo menos 1": "-page must be at least 1",
+	"-page %d passa do 1000º resultado: com -per-page %d, a última página é a %d": "-page %d goes past the 1000th result: with -per-page %d, the last page is %d",
+	"use -sample ou -page, não os dois":                                           "use -sample or -page, not both",
+	"-lucky não combina com -sample ou -page":                                     "-lucky cannot be combined with -sample or -page",
+	"começa a busca nesta página, pulando as anteriores":                          "starts the search at this page, skipping the previous ones",
+	"sorteia N resultados entre todas as páginas, em vez de pegar os N primeiros": "draws N random results across all pages instead of taking the first N",
+	"semente do sorteio de -sample, para repetir a amostra (0 = aleatória)":       "seed for -sample, to repeat a sample (0 = random)",
+
+	// search.go
+	"Consultando a API do GitHub: %s\n":                "Querying GitHub API: %s\n",
+	"resultados parciais (%d itens em %d páginas): %v": "partial results (%d items in %d pages): %v",
+	"não há mais páginas":                              "no more pages",
+
+	// serve.go
+	"serve: escutando em %s":                                                        "serve: listening on %s",
+	"parâmetro q é obrigatório":                                                     "parameter q is required",
+	"limit deve estar entre 1 e %d":                                                 "limit must be between 1 and %d",
+	"banco de snapshots indisponível":                                               "snapshot database unavailable",
+	"nenhum snapshot de %s":                                                         "no snapshots of %s",
+	"serve: falha ao escrever resposta: %v":                                         "serve: failed to write response: %v",
+	"endereço HTTP em que o servidor escuta":                                        "HTTP address the server listens on",
+	"banco SQLite com os snapshots do daemon, servido em /api/history":              "SQLite database with the daemon's snapshots, served at /api/history",
+	"-grace não pode ser negativo":                                                  "-grace cannot be negative",
+	"prazo para terminar as requisições em andamento depois de SIGINT/SIGTERM":      "time allowed for in-flight requests to finish after SIGINT/SIGTERM",
+	"serve: prazo de encerramento esgotado; requisições em andamento interrompidas": "serve: shutdown grace period expired; in-flight requests interrupted",
+	"serve: encerrado":                                                              "serve: stopped",
+
+	// shutdown.go
+	"encerrando: terminando o trabalho em andamento (até %s; outro sinal encerra já)": "shutting down: finishing work in progress (up to %s; another signal exits now)",
+
+	// sink.go
+	"sink inválido %q (use stdout, file:<caminho>, sqlite:<caminho>, webhook:<url> ou s3://<bucket>/<prefixo>)": "invalid sink %q (use stdout, file:<path>, sqlite:<path>, webhook:<url> or s3://<bucket>/<prefix>)",
+	"falha ao abrir %s: %w":              "failed to open %s: %w",
+	"falha ao gravar %s: %w":             "failed to write %s: %w",
+	"webhook retornou status não-OK: %s": "webhook returned non-OK status: %s",
+
+	// sqlite.go
+	"sqlite3 não encontrado no PATH; instale o SQLite para usar o armazenamento local": "sqlite3 not found in PATH; install SQLite to use local storage",
+	"sqlite3: falha ao decodificar resultado: %w":                                      "sqlite3: failed to decode result: %w",
+
+	// starred.go
+	"máximo de repositórios com estrela lidos (as estrelas mais recentes primeiro)": "maximum starred repositories read (most recent stars first)",
+	"mantém só repositórios desta linguagem":                                        "keeps only repositories in this language",
+	"mantém só repositórios com este texto no nome ou na descrição":                 "keeps only repositories with this text in the name or description",
+	"ordenação: starred (estrela mais recente), stars, pushed ou name":              "sorting: starred (most recent star), stars, pushed or name",
+	"query de busca cujos resultados são comparados com as estrelas":                "search query whose results are compared with the stars",
+	"resultados da busca de -against":                                               "results of the -against search",
+	"-sort aceita starred, stars, pushed ou name, recebido %q":                      "-sort accepts starred, stars, pushed or name, got %q",
+	"-against-limit deve estar entre 1 e %d":                                        "-against-limit must be between 1 and %d",
+	"listar estrelas exige autenticação: configure GITHUB_TOKEN ou rode `login`":    "listing stars requires authentication: set GITHUB_TOKEN or run `login`",
+	"%d dos %d resultados já têm estrela.\n\n":                                      "%d of the %d results are already starred.\n\n",
+
+	// stars.go
+	"%s não encontrado (confira o nome e se o token permite marcar estrelas): %w": "%s not found (check the name and whether the token allows starring): %w",
+	"☆ estrela removida: %s\n": "☆ star removed: %s\n",
+	"⭐ estrela marcada: %s\n":  "⭐ starred: %s\n",
+
+	// summary.go
+	"%d encontrados":    "%d matched",
+	"%d mostrados":      "%d shown",
+	"%d chamadas à API": "%d API calls",
+	"%d do cache":       "%d from cache",
+	"busca %d":          "search %d",
+	"quota restante: ":  "quota remaining: ",
+	"%s para clonar":    "%s to clone",
+
+	// tenant.go
+	"header Authorization inválido: use \"Bearer <token>\"":                                         "invalid Authorization header: use \"Bearer <token>\"",
+	"partição de cache inválida: %q":                                                                "invalid cache partition: %q",
+	"-max-tenants deve ser positivo":                                                                "-max-tenants must be positive",
+	"usa o token do header Authorization de cada requisição, com quota e cache separados por token": "uses the token from each request's Authorization header, with quota and cache kept separate per token",
+	"com -multi-tenant, quantos tokens manter ao mesmo tempo":                                       "with -multi-tenant, how many tokens to keep at once",
+
+	// timeout.go
+	"sem resposta em %s: %w": "no response within %s: %w",
+
+	// tokenstore.go
+	"nenhum token salvo; execute o subcomando login":   "no saved token; run the login subcommand",
+	"falha ao localizar diretório de configuração: %w": "failed to locate configuration directory: %w",
+	"falha ao criar diretório de configuração: %w":     "failed to create configuration directory: %w",
+	"keychain não suportado em %s":                     "keychain not supported on %s",
+	"falha ao gerar chave: %w":                         "failed to generate key: %w",
+	"falha ao salvar chave: %w":                        "failed to save key: %w",
+	"falha ao gerar nonce: %w":                         "failed to generate nonce: %w",
+	"falha ao salvar token: %w":                        "failed to save token: %w",
+	"falha ao ler token: %w":                           "failed to read token: %w",
+	"falha ao ler chave: %w":                           "failed to read key: %w",
+	"arquivo de token corrompido":                      "corrupted token file",
+	"falha ao descriptografar token: %w":               "failed to decrypt token: %w",
+	"falha ao inicializar cifra: %w":                   "failed to initialize cipher: %w",
+	"keychain do sistema":                              "system keychain",
+	"token com caracteres inválidos":                   "token contains invalid characters",
+
+	// topics.go
+	"TÓPICO\tREPOSITÓRIOS\t": "TOPIC\tREPOS\t",
+
+	// triage.go
+	"uso: issues-report owner/repo [-limit N]":          "usage: issues-report owner/repo [-limit N]",
+	"%s: %d issues abertas (%d analisadas)\n\n":         "%s: %d open issues (%d analyzed)\n\n",
+	"LABEL\tABERTAS\tIDADE MEDIANA (DIAS)\tMAIS ANTIGA": "LABEL\tOPEN\tMEDIAN AGE (DAYS)\tOLDEST",
+	"(sem label)": "(no label)",
+	"máximo de issues analisadas (máx. 1000)": "maximum issues analyzed (max. 1000)",
+
+	// widget.go
+	"-widget-max-age não pode ser negativo":                         "-widget-max-age cannot be negative",
+	"por quanto tempo navegadores e CDNs podem guardar /widget/top": "how long browsers and CDNs may cache /widget/top",
+
+	// i18n.go
+	"Uso de %s:\n": "Usage of %s:\n",
+	"idioma das mensagens: pt ou en (padrão: conforme LANG)": "message language: pt or en (default: from LANG)",
+	"idioma desconhecido em -lang: %q (use pt ou en)":        "unknown language in -lang: %q (use pt or en)",
+	"a flag -%s precisa de um valor":                         "flag -%s needs a value",
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// IssueSearchResult mapeia a resposta de /search/issues.
+type IssueSearchResult struct {
+	TotalCount int     `json:"total_count"`
+	Items      []Issue `json:"items"`
+}
+
+// Issue mapeia uma issue (ou pull request) devolvida pela busca.
+type Issue struct {
+	Number        int       `json:"number"`
+	Title         string    `json:"title"`
+	URL           string    `json:"html_url"`
+	State         string    `json:"state"`
+	Comments      int       `json:"comments"`
+	Labels        []Label   `json:"labels"`
+	User          Owner     `json:"user"`
+	RepositoryURL string    `json:"repository_url"` // ex: https://api.github.com/repos/owner/repo
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Label mapeia um label de issue.
+type Label struct {
+	Name string `json:"name"`
+}
+
+// RepoFullName extrai "owner/repo" de RepositoryURL.
+func (i Issue) RepoFullName() string {
+	_, name, _ := strings.Cut(i.RepositoryURL, "/repos/")
+	return name
+}
+
+// SearchIssues busca uma página de issues/PRs. A query deve incluir
+// is:issue ou is:pr para não misturar os dois.
+func (c *Client) SearchIssues(ctx context.Context, opts SearchOptions) (*IssueSearchResult, error) {
+	total, items, _, err := searchPage[Issue](ctx, c, "/search/issues", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &IssueSearchResult{TotalCount: total, Items: items}, nil
+}
+
+// SearchAllIssues pagina a busca de issues como SearchAllRepositories.
+func (c *Client) SearchAllIssues(ctx context.Context, opts SearchOptions, limit int) (*IssueSearchResult, error) {
+	total, items, err := collect(ctx, c.IssueIterator(opts), limit)
+	if err != nil {
+		return nil, err
+	}
+	return &IssueSearchResult{TotalCount: total, Items: items}, nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageLinks são os links de paginação do header Link (RFC 8288) de uma
+// resposta da API: as URLs da próxima, anterior, primeira e última
+// páginas. Os ausentes ficam vazios; na última página, por exemplo, não há
+// Next.
+type PageLinks struct {
+	Next  string
+	Prev  string
+	First string
+	Last  string
+}
+
+// ParsePageLinks lê os links de paginação dos headers de uma resposta.
+func ParsePageLinks(h http.Header) PageLinks {
+	rels := parseLinkHeader(h.Values("Link")...)
+	return PageLinks{Next: rels["next"], Prev: rels["prev"], First: rels["first"], Last: rels["last"]}
+}
+
+// NextPage é o número da próxima página; 0 quando esta é a última.
+func (l PageLinks) NextPage() int { return pageNumber(l.Next) }
+
+// PrevPage é o número da página anterior; 0 na primeira.
+func (l PageLinks) PrevPage() int { return pageNumber(l.Prev) }
+
+// LastPage é o número da última página, ou seja, o total de páginas; 0
+// quando a API não informa (resposta de página única, ou já na última).
+func (l PageLinks) LastPage() int { return pageNumber(l.Last) }
+
+// parseLinkHeader converte valores do header Link no mapa rel → URL. Aceita
+// vários valores e vários links por valor, rel com ou sem aspas, vários
+// rels num link (rel="next last") e vírgulas dentro da URL. Se um rel
+// aparece mais de uma vez, vale o primeiro.
+func parseLinkHeader(values ...string) map[string]string {
+	rels := map[string]string{}
+	for _, v := range values {
+		for v != "" {
+			start := strings.IndexByte(v, '<')
+			if start < 0 {
+				break
+			}
+			end := strings.IndexByte(v[start:], '>')
+			if end < 0 {
+				break
+			}
+			target := strings.TrimSpace(v[start+1 : start+end])
+			v = v[start+end+1:]
+
+			// Os parâmetros vão até o próximo link.
+			params := v
+			if next := strings.IndexByte(v, '<'); next >= 0 {
+				params, v = v[:next], v[next:]
+			} else {
+				v = ""
+			}
+			for _, rel := range linkRels(params) {
+				if _, ok := rels[rel]; !ok && target != "" {
+					rels[rel] = target
+				}
+			}
+		}
+	}
+	return rels
+}
+
+// linkRels extrai os valores do parâmetro rel (em minúsculas) dos
+// parâmetros de um link, como `; rel="next", `.
+func linkRels(params string) []string {
+	for _, p := range strings.Split(params, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(p), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		value = strings.TrimRight(value, ", ")
+		value = strings.Trim(value, `"`)
+		return strings.Fields(strings.ToLower(value))
+	}
+	return nil
+}
+
+// pageNumber lê o parâmetro page de uma URL de paginação; 0 se não houver.
+func pageNumber(link string) int {
+	if link == "" {
+		return 0
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.Atoi(u.Query().Get("page"))
+	if err != nil || n < 1 {
+		return 0
+	}
+	return n
+}
+
+// listAll lê até limit itens de um endpoint de lista paginado, 100 por
+// página, seguindo o rel="next" do header Link. prepare, se não for nil,
+// ajusta cada requisição (um Accept próprio, por exemplo).
+func listAll[T any](ctx context.Context, c *Client, path string, params url.Values, limit int, prepare func(*http.Request)) ([]T, error) {
+	var all []T
+	for page := 1; page > 0 && len(all) < limit; {
+		q := url.Values{}
+		for k, v := range params {
+			q[k] = v
+		}
+		q.Set("per_page", strconv.Itoa(maxPerPage))
+		q.Set("page", strconv.Itoa(page))
+
+		req, err := c.newRequest(ctx, http.MethodGet, path, q)
+		if err != nil {
+			return nil, err
+		}
+		if prepare != nil {
+			prepare(req)
+		}
+		var items []T
+		resp, err := c.do(req, &items)
+		if err != nil {
+			return nil, fmt.Errorf(tr("página %d: %w"), page, err)
+		}
+		all = append(all, items...)
+		// Um next que não avança repetiria páginas para sempre: trata como fim.
+		if next := ParsePageLinks(resp.Header).NextPage(); next > page {
+			page = next
+		} else {
+			page = 0
+		}
+	}
+
+	if len(all) > limit {
+		all = all[:limit]
+	}
+	return all, nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   map[string]string
+	}{
+		{
+			name:   "vazio",
+			values: nil,
+			want:   map[string]string{},
+		},
+		{
+			name: "busca do GitHub, primeira página",
+			values: []string{`<https://api.github.com/search/repositories?q=language%3Ago&page=2>; rel="next", ` +
+				`<https://api.github.com/search/repositories?q=language%3Ago&page=34>; rel="last"`},
+			want: map[string]string{
+				"next": "https://api.github.com/search/repositories?q=language%3Ago&page=2",
+				"last": "https://api.github.com/search/repositories?q=language%3Ago&page=34",
+			},
+		},
+		{
+			name: "página do meio, com os quatro rels",
+			values: []string{`<https://api.github.com/repositories/1/forks?page=3>; rel="next", ` +
+				`<https://api.github.com/repositories/1/forks?page=5>; rel="last", ` +
+				`<https://api.github.com/repositories/1/forks?page=1>; rel="first", ` +
+				`<https://api.github.com/repositories/1/forks?page=1>; rel="prev"`},
+			want: map[string]string{
+				"next":  "https://api.github.com/repositories/1/forks?page=3",
+				"last":  "https://api.github.com/repositories/1/forks?page=5",
+				"first": "https://api.github.com/repositories/1/forks?page=1",
+				"prev":  "https://api.github.com/repositories/1/forks?page=1",
+			},
+		},
+		{
+			name:   "vírgula dentro da URL",
+			values: []string{`<https://api.github.com/search/code?q=a,b&page=2>; rel="next"`},
+			want:   map[string]string{"next": "https://api.github.com/search/code?q=a,b&page=2"},
+		},
+		{
+			name:   "rel sem aspas, maiúsculas e espaços extras",
+			values: []string{` < https://x/?page=2 > ;REL=Next ,<https://x/?page=9>;  rel = "last"`},
+			want:   map[string]string{"next": "https://x/?page=2", "last": "https://x/?page=9"},
+		},
+		{
+			name:   "vários rels num link",
+			values: []string{`<https://x/?page=2>; rel="next last"`},
+			want:   map[string]string{"next": "https://x/?page=2", "last": "https://x/?page=2"},
+		},
+		{
+			name:   "outros parâmetros e rels de aviso",
+			values: []string{`<https://github.blog/changelog/x>; rel="deprecation"; type="text/html"`},
+			want:   map[string]string{"deprecation": "https://github.blog/changelog/x"},
+		},
+		{
+			name:   "vários valores do header; vale o primeiro rel",
+			values: []string{`<https://x/?page=2>; rel="next"`, `<https://y/?page=7>; rel="next", <https://y/?page=8>; rel="last"`},
+			want:   map[string]string{"next": "https://x/?page=2", "last": "https://y/?page=8"},
+		},
+		{
+			name:   "malformado",
+			values: []string{`https://x/?page=2; rel="next"`, `<https://x/?page=3; rel="next"`, `<>; rel="last"`},
+			want:   map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLinkHeader(tt.values...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLinkHeader(%q) = %v, quer %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageLinksNumbers(t *testing.T) {
+	h := http.Header{}
+	h.Add("Link", `<https://api.github.com/user/starred?per_page=100&page=3>; rel="next", `+
+		`<https://api.github.com/user/starred?per_page=100&page=12>; rel="last", `+
+		`<https://api.github.com/user/starred?per_page=100&page=1>; rel="prev"`)
+	l := ParsePageLinks(h)
+	if got := l.NextPage(); got != 3 {
+		t.Errorf("NextPage() = %d, quer 3", got)
+	}
+	if got := l.LastPage(); got != 12 {
+		t.Errorf("LastPage() = %d, quer 12", got)
+	}
+	if got := l.PrevPage(); got != 1 {
+		t.Errorf("PrevPage() = %d, quer 1", got)
+	}
+	if l.First != "" {
+		t.Errorf("First = %q, quer vazio", l.First)
+	}
+
+	// Última página: o GitHub manda só prev e first.
+	h = http.Header{}
+	h.Set("Link", `<https://x/?page=11>; rel="prev", <https://x/?page=1>; rel="first"`)
+	l = ParsePageLinks(h)
+	if l.NextPage() != 0 || l.LastPage() != 0 {
+		t.Errorf("última página: NextPage() = %d, LastPage() = %d, quer 0 e 0", l.NextPage(), l.LastPage())
+	}
+
+	for _, link := range []string{"", "::", "https://x/", "https://x/?page=abc", "https://x/?page=0", "https://x/?page=-2"} {
+		if got := pageNumber(link); got != 0 {
+			t.Errorf("pageNumber(%q) = %d, quer 0", link, got)
+		}
+	}
+}
+
+// pagedServer serve total itens numerados, per por página, com o header
+// Link como o do GitHub.
+func pagedServer(t *testing.T, total, per int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		last := (total + per - 1) / per
+		if page < last {
+			next := *r.URL
+			q := next.Query()
+			q.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = q.Encode()
+			q.Set("page", strconv.Itoa(last))
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?%s>; rel="next", <http://%s%s?%s>; rel="last"`,
+				r.Host, next.Path, next.RawQuery, r.Host, next.Path, q.Encode()))
+		}
+		var items []map[string]int
+		for i := (page-1)*per + 1; i <= min(page*per, total); i++ {
+			items = append(items, map[string]int{"id": i})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(items)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestListAllFollowsLinks(t *testing.T) {
+	srv := pagedServer(t, 250, maxPerPage)
+	c, err := NewClient(WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type item struct{ ID int }
+	for _, tt := range []struct{ limit, want int }{{1000, 250}, {150, 150}, {100, 100}} {
+		items, err := listAll[item](context.Background(), c, "/list", nil, tt.limit, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != tt.want || items[len(items)-1].ID != tt.want {
+			t.Errorf("limit %d: %d itens (último %d), quer %d", tt.limit, len(items), items[len(items)-1].ID, tt.want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Limites do trecho do README mostrado por -lucky.
+const (
+	readmeExcerptLines = 12
+	readmeExcerptChars = 800
+)
+
+// profile é o perfil detalhado de um repositório mostrado por -lucky.
+type profile struct {
+	Repository    Repository `json:"repository"`
+	LatestRelease *Release   `json:"latest_release"`
+	ReadmeExcerpt string     `json:"readme_excerpt"`
+}
+
+// fetchProfile completa o repositório com todos os enriquecimentos que
+// ainda não foram aplicados (inclusive a última release) e o início do
+// README.
+func fetchProfile(ctx context.Context, client *Client, repo Repository, applied []Stage) (*profile, error) {
+	done := map[string]bool{}
+	for _, s := range applied {
+		done[s.Name()] = true
+	}
+	var missing []Stage
+	for _, s := range enrichers {
+		if !done[s.Name()] {
+			missing = append(missing, s)
+		}
+	}
+	repos := []Repository{repo}
+	if err := runPipeline(ctx, client, repos, missing); err != nil {
+		return nil, err
+	}
+
+	readme, err := client.GetReadme(ctx, repo.FullName)
+	if err != nil {
+		return nil, fmt.Errorf("README: %w", err)
+	}
+	return &profile{Repository: repos[0], LatestRelease: repos[0].LatestRelease, ReadmeExcerpt: readmeExcerpt(readme)}, nil
+}
+
+// readmeExcerpt devolve o começo do README, sem badges, imagens e HTML,
+// que no terminal são só ruído.
+func readmeExcerpt(readme string) string {
+	var lines []string
+	size := 0
+	blank := true // evita linhas em branco repetidas ou no início
+	for _, line := range strings.Split(readme, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "<") || strings.HasPrefix(trimmed, "![") || strings.HasPrefix(trimmed, "[![") {
+			continue
+		}
+		if trimmed == "" {
+			if !blank {
+				lines = append(lines, "")
+			}
+			blank = true
+			continue
+		}
+		if len(lines) == readmeExcerptLines || size+len(line) > readmeExcerptChars {
+			lines = append(lines, "…")
+			break
+		}
+		lines = append(lines, strings.TrimRight(line, " \t\r"))
+		size += len(line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// writeProfile escreve o perfil em texto ou JSON.
+func writeProfile(w io.Writer, format string, p *profile) error {
+	if format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(p); err != nil {
+			return fmt.Errorf(tr("falha ao codificar JSON: %w"), err)
+		}
+		return nil
+	}
+
+	r := p.Repository
+	fmt.Fprintf(w, "🍀 %s\n", r.FullName)
+	if r.Description != "" {
+		fmt.Fprintf(w, "   %s\n", r.Description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "   🔗 URL:           %s\n", r.URL)
+	fmt.Fprintf(w, tr("   ⭐ Estrelas:      %d · forks %d · issues abertas %d\n"), r.Stars, r.Forks, r.OpenIssues)
+	if langs := languagesSummary(r); langs != "" {
+		fmt.Fprintf(w, tr("   💬 Linguagens:    %s\n"), langs)
+	} else if r.Language != "" {
+		fmt.Fprintf(w, tr("   💬 Linguagem:     %s\n"), r.Language)
+	}
+	license := tr("nenhuma detectada")
+	if r.License != nil {
+		license = r.License.Name
+	}
+	fmt.Fprintf(w, tr("   ⚖  Licença:       %s\n"), license)
+	if len(r.Topics) > 0 {
+		fmt.Fprintf(w, tr("   🏷  Tópicos:       %s\n"), strings.Join(r.Topics, ", "))
+	}
+	fmt.Fprintf(w, tr("   📅 Criado em:     %s · último push %s\n"), r.CreatedAt.Format("2006-01-02"), r.PushedAt.Format("2006-01-02"))
+	if r.unavailable("activity") {
+		fmt.Fprintf(w, tr("   📝 Último commit: %s\n"), unavailableValue)
+	} else if r.LastCommitAt != nil {
+		fmt.Fprintf(w, tr("   📝 Último commit: %s\n"), r.LastCommitAt.Format("2006-01-02"))
+	}
+	ci := r.CIStatus
+	if r.unavailable("ci") {
+		ci = unavailableValue
+	}
+	if ci != "" {
+		fmt.Fprintf(w, "   🚦 CI:            %s\n", ci)
+	}
+	if sec := securitySummary(r); sec != "" {
+		fmt.Fprintf(w, tr("   🛡  Segurança:     %s\n"), sec)
+	}
+	if heat := heatmapSummary(r); heat != "" {
+		fmt.Fprintf(w, "   📈 Commits:       %s\n", heat)
+	}
+	if len(r.Notes) > 0 {
+		fmt.Fprintf(w, tr("   📝 Notas:         %s\n"), notesSummary(r.Notes))
+	}
+	if r.unavailable("release") {
+		fmt.Fprintf(w, tr("   📦 Última release: %s\n"), unavailableValue)
+	} else if p.LatestRelease != nil {
+		rel := p.LatestRelease
+		fmt.Fprintf(w, tr("   📦 Última release: %s (%s) %s\n"), rel.TagName, rel.PublishedAt.Format("2006-01-02"), rel.URL)
+	} else {
+		fmt.Fprint(w, tr("   📦 Última release: nenhuma\n"))
+	}
+
+	if p.ReadmeExcerpt != "" {
+		fmt.Fprint(w, "\n--- README ---\n")
+		fmt.Fprintln(w, p.ReadmeExcerpt)
+	}
+	return nil
+}
+
+// openBrowser abre url no navegador padrão do sistema.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf(tr("falha ao abrir o navegador: %w"), err)
+	}
+	return nil
+}
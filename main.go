@@ -1,121 +1,668 @@
-package main
-
-import (
-	"encoding/json"
-	"fmt"
-	"io"
-	"log"
-	"net/http"
-	"net/url"
-	"time"
-)
-
-const GitHubAPIURL = "https://api.github.com/search/repositories"
-// SearchResult mapeia os campos principais da resposta da API do GitHub
-type SearchResult struct {
-	TotalCount int          `json:"total_count"`
-	Items      []Repository `json:"items"` // Um slice de repositórios
-}
-
-// Repository mapeia os campos de um item de repositório individual
-// Estamos interessados apenas em alguns campos (as "features").
-type Repository struct {
-	Name        string `json:"name"`
-	FullName    string `json:"full_name"`
-	URL         string `json:"html_url"`
-	Description string `json:"description"`
-	Stars       int    `json:"stargazers_count"` // A "feature" que usaremos para ordenar
-	Forks       int    `json:"forks_count"`
-}
-
-/**
- * searchRepositories é a função principal que consome a API.
- * Ela é responsável por construir a query, fazer a chamada e decodificar a resposta.
- */
-func searchRepositories(client *http.Client, query, sortBy, order string) (*SearchResult, error) {
-	// 1. Construir a URL com parâmetros de forma segura
-	params := url.Values{}
-	params.Add("q", query)   // O termo de busca (ex: "language:go")
-	params.Add("sort", sortBy) // A "FEATURE" para ordenar (ex: "stars")
-	params.Add("order", order) // A direção (ex: "desc")
-
-	fullURL := fmt.Sprintf("%s?%s", GitHubAPIURL, params.Encode())
-	log.Printf("Querying GitHub API: %s\n", fullURL)
-
-	// 2. Criar a requisição GET
-	req, err := http.NewRequest("GET", fullURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("falha ao criar requisição: %w", err)
-	}
-
-	// 3. Definir Headers OBRIGATÓRIOS da API do GitHub
-	// Sem eles, a API retornará 403 Forbidden.
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "my-golang-app") // A API exige um User-Agent
-
-	// 4. Executar a requisição
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("falha ao executar requisição: %w", err)
-	}
-	defer resp.Body.Close() // Boa prática: sempre fechar o corpo da resposta
-
-	// 5. Tratar códigos de status não-OK
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API do GitHub retornou status não-OK: %s", resp.Status)
-	}
-
-	// 6. Ler o corpo da resposta
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("falha ao ler corpo da resposta: %w", err)
-	}
-
-	// 7. Decodificar (Unmarshal) o JSON na nossa struct
-	var result SearchResult
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("falha ao decodificar JSON: %w", err)
-	}
-
-	return &result, nil
-}
-
-func main() {
-	// Criamos um cliente HTTP com um timeout. Isso é uma boa prática
-	// para evitar que nossa aplicação fique presa indefinidamente.
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	// --- Definição da nossa busca ---
-	// Termo de busca: repositórios da linguagem Go
-	query := "language:go"
-	// A "FEATURE" pela qual queremos ordenar
-	sortByFeature := "stars"
-	// A ordem da ordenação
-	order := "desc"
-	// ---------------------------------
-
-	fmt.Printf("Buscando repositórios no GitHub...\nQuery: '%s', Sort By: '%s', Order: '%s'\n\n", query, sortByFeature, order)
-
-	// Chama nossa função
-	result, err := searchRepositories(client, query, sortByFeature, order)
-	if err != nil {
-		log.Fatalf("ERRO: %v", err) // `log.Fatalf` encerra o programa em caso de erro
-	}
-
-	// --- Aqui "tratamos os dados de resposta" ---
-	// Vamos apenas imprimir os 10 primeiros de forma organizada.
-	fmt.Printf("Encontrados %d repositórios. Mostrando os 10 primeiros:\n", result.TotalCount)
-	fmt.Println("---------------------------------------------------------")
-
-	// Itera sobre os items (repositórios) retornados
-	for i, repo := range result.Items {
-		if i >= 10 { // Limita a 10 resultados
-			break
-		}
-		fmt.Printf("#%d: %s\n", i+1, repo.FullName)
-		fmt.Printf("   ⭐ Estrelas: %d\n", repo.Stars)
-		fmt.Printf("   🍴 Forks:    %d\n", repo.Forks)
-		fmt.Printf("   🔗 URL:       %s\n", repo.URL)
-		fmt.Printf("   %s\n\n", repo.Description)
-	}
-}
\ No newline at end of file
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"time"
+)
+
+// subcommands mapeia o nome de cada subcomando para sua implementação.
+// Sem subcomando, o programa executa a busca padrão.
+var subcommands = map[string]func(args []string) error{
+	"login":             runLogin,
+	"auth":              runAuth,
+	"compare-languages": runCompareLanguages,
+	"gists":             runGists,
+	"export":            runExport,
+	"issues-report":     runIssuesReport,
+	"first-issues":      runFirstIssues,
+	"cache":             runCache,
+	"daemon":            runDaemon,
+	"chart":             runChart,
+	"serve":             runServe,
+	"forks":             runForks,
+	"dependents":        runDependents,
+	"diff":              runDiff,
+	"awesome":           runAwesome,
+	"deps":              runDeps,
+	"review-load":       runReviewLoad,
+	"starred":           runStarred,
+	"note":              runNote,
+}
+
+func main() {
+	// -lang e -error-format antes do subcomando valem para todos.
+	args, err := langFromArgs(os.Args[1:])
+	if err != nil {
+		exitWithError(err)
+	}
+	if len(args) > 0 {
+		if cmd, ok := subcommands[args[0]]; ok {
+			if err := cmd(args[1:]); err != nil {
+				exitWithError(err)
+			}
+			return
+		}
+	}
+
+	if err := runSearch(args); err != nil {
+		exitWithError(err) // encerra o programa com o código de saída da classe do erro
+	}
+}
+
+// clientFlags são as flags de conexão comuns à busca e aos subcomandos.
+type clientFlags struct {
+	recordDir  string
+	replayDir  string
+	demo       bool
+	userAgent  string
+	apiVersion string
+	cacheTTL   time.Duration
+	emptyTTL   time.Duration
+	invalidTTL time.Duration
+
+	tokensFile    string
+	poolAnonymous bool
+	maxBodyMB     int
+
+	runTimeout     time.Duration
+	requestTimeout time.Duration
+	searchTimeout  time.Duration
+	enrichTimeout  time.Duration
+}
+
+// addClientFlags registra as flags de conexão em fs.
+func addClientFlags(fs *flag.FlagSet) *clientFlags {
+	cf := &clientFlags{}
+	fs.StringVar(&cf.recordDir, "record", "", "grava as respostas da API neste diretório")
+	fs.StringVar(&cf.replayDir, "replay", "", "responde a partir das gravações deste diretório, sem rede")
+	fs.BoolVar(&cf.demo, "demo", false, "usa dados de exemplo embutidos, sem rede nem token (demonstrações, aulas, CI)")
+	fs.StringVar(&cf.userAgent, "user-agent", defaultUserAgent(), "User-Agent enviado à API")
+	fs.StringVar(&cf.apiVersion, "api-version", defaultAPIVersion, "versão da API REST enviada em X-GitHub-Api-Version (vazio = padrão do GitHub)")
+	fs.DurationVar(&cf.cacheTTL, "cache-ttl", 10*time.Minute, "validade do cache de respostas (0 desliga o cache)")
+	fs.DurationVar(&cf.emptyTTL, "cache-ttl-empty", defaultEmptyTTL, "validade do cache de resultados vazios (0 não os guarda)")
+	fs.DurationVar(&cf.invalidTTL, "cache-ttl-invalid", defaultInvalidTTL, "validade do cache de queries rejeitadas com 422 (0 não as guarda)")
+	fs.StringVar(&cf.tokensFile, "tokens-file", "", "arquivo com tokens extras, um por linha, usados em rodízio (também GITHUB_TOKENS)")
+	fs.BoolVar(&cf.poolAnonymous, "pool-anonymous", false, "inclui a quota anônima no rodízio de tokens")
+	fs.IntVar(&cf.maxBodyMB, "max-body-mb", defaultMaxBodySize>>20, "tamanho máximo de cada resposta da API, em MB (0 = sem limite)")
+	fs.DurationVar(&cf.runTimeout, "timeout", 0, "prazo da execução inteira; no daemon, de cada execução de job; no serve, de cada busca (0 = sem prazo)")
+	fs.DurationVar(&cf.requestTimeout, "request-timeout", defaultTimeout, "prazo de cada requisição à API, por tentativa (0 = sem prazo)")
+	fs.DurationVar(&cf.searchTimeout, "search-timeout", 0, "prazo de cada página da busca, com esperas e repetições (0 = só -request-timeout)")
+	fs.DurationVar(&cf.enrichTimeout, "enrich-timeout", 0, "prazo de cada enriquecimento por repositório (0 = só -request-timeout)")
+	return cf
+}
+
+// context devolve o contexto da execução, com o prazo de -timeout.
+func (cf *clientFlags) context() (context.Context, context.CancelFunc) {
+	return withTimeout(context.Background(), cf.runTimeout)
+}
+
+// cachePolicy monta a política de cache das flags. Com -cache-ttl 0 o
+// cache fica todo desligado, e as respostas negativas nunca valem mais
+// que as normais.
+func (cf *clientFlags) cachePolicy() CachePolicy {
+	if cf.cacheTTL <= 0 {
+		return CachePolicy{}
+	}
+	return CachePolicy{OK: cf.cacheTTL, Empty: min(cf.emptyTTL, cf.cacheTTL), Invalid: min(cf.invalidTTL, cf.cacheTTL)}
+}
+
+// offline informa se as respostas vêm de gravações ou dos dados de
+// exemplo, situação em que a falta de token não impede nada.
+func (cf *clientFlags) offline() bool {
+	return cf.replayDir != "" || cf.demo
+}
+
+// newClient cria o Client conforme as flags, com o token escolhido por
+// resolveToken.
+func (cf *clientFlags) newClient() (*Client, error) {
+	if cf.demo {
+		return cf.newDemoClient()
+	}
+	token := resolveToken()
+	extra, err := readTokens(cf.tokensFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := newTokenPool(append([]string{token}, extra...), cf.poolAnonymous)
+
+	opts := append(cf.options(), WithToken(token))
+	if cf.replayDir == "" {
+		// O limite por minuto da busca vale por token, então, com pool, soma.
+		searchRate := searchRatePerMinute(token != "")
+		if pool.Len() > 1 {
+			searchRate = pool.authenticated() * searchRatePerMinute(true)
+			if cf.poolAnonymous {
+				searchRate += searchRatePerMinute(false)
+			}
+		}
+		opts = append(opts, WithRateLimiter(newRateLimiter(searchRate)))
+	}
+	client, err := NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	// Tokens extras ou a quota anônima formam um pool com o token principal.
+	if pool.Len() > 1 {
+		client.SetTokenPool(pool)
+	}
+	return client, nil
+}
+
+// options são as opções de Client comuns a todos os modos de conexão.
+func (cf *clientFlags) options() []ClientOption {
+	// O cache fica por dentro do gravador, para que -record grave também
+	// as respostas servidas do cache.
+	return []ClientOption{
+		WithUserAgent(cf.userAgent),
+		WithAPIVersion(cf.apiVersion),
+		WithMaxBodySize(int64(cf.maxBodyMB) << 20),
+		WithTimeout(cf.requestTimeout),
+		WithSearchTimeout(cf.searchTimeout),
+		WithEnrichTimeout(cf.enrichTimeout),
+		WithCachePolicy(cf.cachePolicy()),
+		WithMiddleware(func(next http.RoundTripper) (http.RoundTripper, error) {
+			return newTransport(cf.recordDir, cf.replayDir, next)
+		}),
+	}
+}
+
+// newTenantClient cria o Client de um tenant do serve: o token que veio na
+// requisição, sem pool, com o próprio limitador de busca e o cache na
+// partição do tenant.
+func (cf *clientFlags) newTenantClient(token, partition string) (*Client, error) {
+	if cf.demo {
+		return cf.newDemoClient()
+	}
+	opts := append(cf.options(), WithToken(token), WithCachePartition(partition))
+	if cf.replayDir == "" {
+		opts = append(opts, WithRateLimiter(newRateLimiter(searchRatePerMinute(true))))
+	}
+	return NewClient(opts...)
+}
+
+// newDemoClient cria o Client de -demo: anônimo, sem cache nem limitador
+// e respondendo só com os dados de exemplo embutidos.
+func (cf *clientFlags) newDemoClient() (*Client, error) {
+	if cf.recordDir != "" || cf.replayDir != "" {
+		return nil, errors.New(tr("use -demo sem -record e -replay"))
+	}
+	demo, err := newDemoTransport()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(
+		WithUserAgent(cf.userAgent),
+		WithAPIVersion(cf.apiVersion),
+		WithMaxBodySize(int64(cf.maxBodyMB)<<20),
+		WithTimeout(cf.requestTimeout),
+		WithSearchTimeout(cf.searchTimeout),
+		WithEnrichTimeout(cf.enrichTimeout),
+		WithMiddleware(func(http.RoundTripper) (http.RoundTripper, error) { return demo, nil }),
+	)
+}
+
+// searchFlags são as flags que descrevem uma busca de repositórios,
+// compartilhadas pela busca padrão e pelos subcomandos que partem dela.
+type searchFlags struct {
+	query   string
+	sortBy  string
+	order   string
+	limit   int
+	perPage int
+	page    int
+}
+
+// addSearchFlags registra as flags de busca em fs; defaultLimit é o
+// -limit padrão de cada comando.
+func addSearchFlags(fs *flag.FlagSet, defaultLimit int) *searchFlags {
+	sf := &searchFlags{}
+	fs.StringVar(&sf.query, "q", "language:go", "termo de busca (aceita qualificadores do GitHub)")
+	fs.StringVar(&sf.sortBy, "sort", "stars", "campo de ordenação: stars, forks, updated, best-match (relevância)... ou health (no cliente)")
+	fs.StringVar(&sf.order, "order", "desc", "direção da ordenação: asc ou desc")
+	fs.IntVar(&sf.limit, "limit", defaultLimit, "total de resultados desejados (máx. 1000)")
+	fs.IntVar(&sf.perPage, "per-page", 30, "resultados por página da API (1..100)")
+	fs.IntVar(&sf.page, "page", 1, "começa a busca nesta página, pulando as anteriores")
+	return sf
+}
+
+func (sf *searchFlags) validate() error {
+	if sf.perPage < 1 || sf.perPage > maxPerPage {
+		return invalid(fmt.Errorf(tr("-per-page deve estar entre 1 e %d"), maxPerPage))
+	}
+	if sf.limit < 1 || sf.limit > maxSearchResults {
+		return invalid(fmt.Errorf(tr("-limit deve estar entre 1 e %d"), maxSearchResults))
+	}
+	if sf.page < 1 {
+		return invalid(errors.New(tr("-page deve ser pelo menos 1")))
+	}
+	// Além do 1000º resultado a API responde 422.
+	if last := (maxSearchResults + sf.perPage - 1) / sf.perPage; sf.page > last {
+		return invalid(fmt.Errorf(tr("-page %d passa do 1000º resultado: com -per-page %d, a última página é a %d"), sf.page, sf.perPage, last))
+	}
+	return nil
+}
+
+// sortBestMatch é a ordenação por relevância, a padrão da API quando não
+// há parâmetro sort.
+const sortBestMatch = "best-match"
+
+// options converte as flags em SearchOptions. Com -sort best-match, sort e
+// order ficam de fora da URL, que é como a API ordena por relevância.
+func (sf *searchFlags) options() SearchOptions {
+	if sf.sortBy == sortBestMatch {
+		return SearchOptions{Query: sf.query, PerPage: sf.perPage, Page: sf.page}
+	}
+	return SearchOptions{Query: sf.query, Sort: sf.sortBy, Order: sf.order, PerPage: sf.perPage, Page: sf.page}
+}
+
+// searchJob descreve o processamento completo de uma busca: a chamada à
+// API e o pós-processamento no cliente (filtros, enriquecimento e
+// ordenação local). O mesmo job é reaproveitado para várias queries.
+type searchJob struct {
+	opts   SearchOptions
+	limit  int
+	ranker Ranker // reordenação no cliente; nil mantém a ordem da API
+	dates  dateFilter
+	topics topicFilter
+	active time.Duration // janela de -active; 0 desliga
+	enrich []Stage
+	strict bool // falha em vez de seguir com resultados parciais
+
+	sample int        // sorteia este número de resultados entre as páginas; 0 desliga
+	rng    *rand.Rand // sorteio de -sample
+
+	minScore float64 // relevância mínima (Score); 0 desliga
+	maxSize  int     // tamanho máximo em KB (Size); 0 desliga
+}
+
+// run executa o job para uma query.
+func (j searchJob) run(ctx context.Context, client *Client, query string) (*SearchResult, error) {
+	now := time.Now()
+	opts := j.opts
+	opts.Query = query
+	if j.active > 0 {
+		opts.Query = withQualifiers(query, activeQualifiers(j.active, now)...)
+	}
+	// O limite de tamanho vai também na query, para que a API não gaste
+	// páginas com repositórios que seriam descartados.
+	if j.maxSize > 0 {
+		opts.Query = withQualifiers(opts.Query, fmt.Sprintf("size:<=%d", j.maxSize))
+	}
+	keep := func(items []Repository) []Repository {
+		items = filterByDate(items, j.dates, now)
+		items = filterByTopic(items, j.topics)
+		items = filterByScore(items, j.minScore)
+		return filterBySize(items, j.maxSize)
+	}
+
+	// O sorteio só sabe quais repositórios quer depois da primeira página,
+	// então enriquece depois de buscar; a busca normal enriquece cada
+	// página enquanto busca a seguinte.
+	var result *SearchResult
+	var err error
+	if j.sample > 0 {
+		result, err = client.SampleRepositories(ctx, opts, j.sample, j.rng)
+		if err != nil && (j.strict || result == nil) {
+			return nil, err
+		}
+		result.Items = keep(result.Items)
+		if err := runPipeline(ctx, client, result.Items, j.enrich); err != nil {
+			return nil, err
+		}
+	} else {
+		result, err = searchPipeline(ctx, client, opts, j.limit, keep, j.enrich)
+		if err != nil && (j.strict || result == nil) {
+			return nil, err
+		}
+	}
+	if j.active > 0 {
+		result.Items = filterActive(result.Items, j.active, now)
+	}
+	if j.ranker != nil {
+		j.ranker.Rank(result.Items, now)
+	}
+	return result, nil
+}
+
+// runSearch executa a busca padrão de repositórios.
+func runSearch(args []string) error {
+	start := time.Now()
+	fs := newFlagSet("search")
+	sf := addSearchFlags(fs, 10)
+	format := fs.String("format", formatText, "formato de saída: text, table, csv ou json")
+	fieldsSpec := fs.String("fields", defaultFields, "colunas para table/csv/json, separadas por vírgula")
+	pushedWithin := fs.String("pushed-within", "", "mantém só repositórios com push nesse período (ex: 90d)")
+	updatedWithin := fs.String("updated-within", "", "mantém só repositórios atualizados nesse período")
+	createdWithin := fs.String("created-within", "", "mantém só repositórios criados nesse período")
+	topic := fs.String("topic", "", "mantém só repositórios com todos estes tópicos, separados por vírgula")
+	excludeTopic := fs.String("exclude-topic", "", "descarta repositórios com qualquer um destes tópicos")
+	active := fs.Bool("active", false, "só projetos mantidos: push recente, não arquivados, commit confirmado na branch padrão")
+	activeWithin := fs.String("active-within", "90d", "janela de atividade usada por -active")
+	enrichSpec := fs.String("enrich", "", "enriquecimentos por repositório, separados por vírgula: languages, release, ci, security, activity, heatmap")
+	security := fs.Bool("security", false, "atalho para incluir security em -enrich")
+	groupBy := fs.String("group-by", "", "agrupa os resultados; valores aceitos: owner")
+	cloud := fs.Bool("topic-cloud", false, "mostra os tópicos mais frequentes no resultado em vez da lista")
+	cluster := fs.Bool("cluster", false, "agrupa repositórios de descrição parecida")
+	clusterThreshold := fs.Float64("cluster-threshold", defaultClusterThreshold, "similaridade mínima (0..1) para -cluster")
+	queriesFile := fs.String("queries-file", "", "arquivo com uma query por linha (- para stdin)")
+	concurrency := fs.Int("concurrency", 1, "queries do lote executadas em paralelo")
+	outDir := fs.String("out-dir", "", "no modo lote, grava um arquivo por query e o combinado neste diretório")
+	var sinks sinkList
+	fs.Var(&sinks, "sink", "também entrega os resultados a um destino (repetível): stdout, file:, sqlite:, webhook:, s3://")
+	rankName := fs.String("rank", "", "reordena no cliente: stars, forks, velocity, health ou weighted")
+	rankWeights := fs.String("rank-weights", "", "pesos do -rank weighted, ex: stars=0.6,recency=0.4")
+	cf := addClientFlags(fs)
+	budgetMax := fs.Int("budget", 0, "máximo de chamadas à API nesta execução (0 = apenas a quota)")
+	budgetMode := fs.String("budget-mode", budgetWarn, "quando o plano não couber: warn, prompt ou downscale")
+	maxSizeMB := fs.Float64("max-size-mb", 0, "mantém só repositórios com até este tamanho em MB (0 = sem limite)")
+	minScore := fs.Float64("min-score", 0, "mantém só repositórios com relevância (score) pelo menos igual a este valor")
+	strict := fs.Bool("strict", false, "falha se alguma página da busca falhar, em vez de mostrar os resultados parciais")
+	quiet := fs.Bool("quiet", false, "não mostra o progresso no stderr")
+	starN := fs.Int("star-top", 0, "marca com estrela os N primeiros resultados (exige token)")
+	unstarN := fs.Int("unstar-top", 0, "tira a estrela dos N primeiros resultados (exige token)")
+	lucky := fs.Bool("lucky", false, "pega só o primeiro resultado e mostra o perfil detalhado dele")
+	openURL := fs.Bool("open", false, "com -lucky, abre o repositório no navegador em vez de mostrar o perfil")
+	sample := fs.Int("sample", 0, "sorteia N resultados entre todas as páginas, em vez de pegar os N primeiros")
+	seed := fs.Int64("seed", 0, "semente do sorteio de -sample, para repetir a amostra (0 = aleatória)")
+	showVersion := fs.Bool("version", false, "mostra a versão e sai")
+	fs.Parse(args)
+
+	if *showVersion {
+		fmt.Println(versionString())
+		return nil
+	}
+
+	if err := sf.validate(); err != nil {
+		return err
+	}
+	fields, err := parseFields(*fieldsSpec)
+	if err != nil {
+		return err
+	}
+	var dates dateFilter
+	for _, f := range []struct {
+		flag  string
+		value string
+		dst   *time.Duration
+	}{
+		{"-pushed-within", *pushedWithin, &dates.PushedWithin},
+		{"-updated-within", *updatedWithin, &dates.UpdatedWithin},
+		{"-created-within", *createdWithin, &dates.CreatedWithin},
+	} {
+		if *f.dst, err = parseAge(f.value); err != nil {
+			return fmt.Errorf("%s: %w", f.flag, err)
+		}
+	}
+	if *security {
+		*enrichSpec += ",security"
+	}
+	var activeWindow time.Duration
+	if *active {
+		if activeWindow, err = parseAge(*activeWithin); err != nil {
+			return fmt.Errorf("-active-within: %w", err)
+		}
+		if activeWindow <= 0 {
+			return invalid(errors.New(tr("-active-within deve ser positivo")))
+		}
+		*enrichSpec += ",activity"
+	}
+	ranker, err := parseRanker(*rankName, *rankWeights)
+	if err != nil {
+		return err
+	}
+	if ranker == nil && sf.sortBy == "health" {
+		ranker = rankers["health"]
+	}
+	enrichList, err := parseEnrichers(*enrichSpec)
+	if err != nil {
+		return err
+	}
+	if *groupBy != "" && *groupBy != "owner" {
+		return invalid(fmt.Errorf(tr("-group-by aceita apenas owner, recebido %q"), *groupBy))
+	}
+	if n := countTrue(*groupBy != "", *cluster, *cloud); n > 1 {
+		return invalid(errors.New(tr("use apenas um entre -group-by, -cluster e -topic-cloud")))
+	}
+	if *clusterThreshold <= 0 || *clusterThreshold > 1 {
+		return invalid(errors.New(tr("-cluster-threshold deve estar entre 0 e 1")))
+	}
+	if *minScore < 0 {
+		return invalid(errors.New(tr("-min-score não pode ser negativo")))
+	}
+	if *maxSizeMB < 0 {
+		return invalid(errors.New(tr("-max-size-mb não pode ser negativo")))
+	}
+	if *starN < 0 || *unstarN < 0 {
+		return invalid(errors.New(tr("-star-top e -unstar-top não podem ser negativos")))
+	}
+	if *starN > 0 && *unstarN > 0 {
+		return invalid(errors.New(tr("use -star-top ou -unstar-top, não os dois")))
+	}
+	if (*starN > 0 || *unstarN > 0) && (*queriesFile != "" || *lucky) {
+		return invalid(errors.New(tr("-star-top e -unstar-top não combinam com -queries-file ou -lucky")))
+	}
+	if *sample < 0 || *sample > maxSearchResults {
+		return invalid(fmt.Errorf(tr("-sample deve estar entre 1 e %d"), maxSearchResults))
+	}
+	if *sample > 0 && sf.page > 1 {
+		return invalid(errors.New(tr("use -sample ou -page, não os dois")))
+	}
+	if *lucky && (*sample > 0 || sf.page > 1) {
+		return invalid(errors.New(tr("-lucky não combina com -sample ou -page")))
+	}
+	if *sample > 0 {
+		// O planner e o orçamento contam a amostra como o -limit.
+		sf.limit = *sample
+	}
+	if *openURL && !*lucky {
+		return invalid(errors.New(tr("-open só vale com -lucky")))
+	}
+	if *lucky {
+		if *queriesFile != "" || countTrue(*groupBy != "", *cluster, *cloud) > 0 {
+			return invalid(errors.New(tr("-lucky não combina com -queries-file, -group-by, -cluster ou -topic-cloud")))
+		}
+		// Sem filtros nem ordenação no cliente, o primeiro da API já é o
+		// escolhido; com eles, o primeiro só é conhecido depois de processar
+		// todos os -limit resultados.
+		if ranker == nil && dates == (dateFilter{}) && *topic == "" && *excludeTopic == "" && !*active && *minScore == 0 && *maxSizeMB == 0 {
+			sf.limit, sf.perPage = 1, 1
+		}
+	}
+
+	queries := []string{sf.query}
+	if *queriesFile != "" {
+		if queries, err = readQueries(*queriesFile); err != nil {
+			return err
+		}
+	}
+	for _, q := range queries {
+		if err := validateQuery(q); err != nil {
+			return err
+		}
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+
+	// Estrelas são do usuário autenticado; melhor falhar antes de buscar.
+	if (*starN > 0 || *unstarN > 0) && client.token == "" && !cf.offline() {
+		return needsAuth(errors.New(tr("marcar estrelas exige autenticação: configure GITHUB_TOKEN ou rode `login`")))
+	}
+
+	client.SetProgress(newProgressFlag(*quiet))
+
+	batch := *queriesFile != ""
+	if *format == formatText && !batch && !*lucky {
+		fmt.Printf(tr("Buscando repositórios no GitHub...\nQuery: '%s', ordenação: '%s', direção: '%s'\n\n"), sf.query, sf.sortBy, sf.order)
+	}
+
+	ctx, cancel := cf.context()
+	defer cancel()
+
+	// Verifica token e quota antes de gastar chamadas de busca
+	plan := runPlan{Queries: len(queries), Limit: sf.limit, PerPage: sf.perPage, Enrich: enrichCalls(enrichList), Sample: *sample > 0}
+	plan, err = preflight(ctx, client, plan, budgetPolicy{Max: *budgetMax, Mode: *budgetMode, In: os.Stdin})
+	if err != nil {
+		return err
+	}
+
+	job := searchJob{
+		opts:   sf.options(),
+		limit:  plan.Limit,
+		ranker: ranker,
+		dates:  dates,
+		topics: topicFilter{Include: parseTopics(*topic), Exclude: parseTopics(*excludeTopic)},
+		active: activeWindow,
+		enrich: enrichList,
+		strict: *strict,
+
+		minScore: *minScore,
+		maxSize:  int(math.Ceil(*maxSizeMB * 1024)),
+	}
+	if *sample > 0 {
+		job.sample = plan.Limit
+		job.rng = newSampleRand(*seed)
+	}
+	job.opts.PerPage = plan.PerPage
+	// O health score não existe na API: buscamos por estrelas e
+	// reordenamos localmente.
+	if sf.sortBy == "health" {
+		job.opts.Sort = "stars"
+	}
+	// O planner pode ter desligado o enriquecimento para caber na quota.
+	if plan.Enrich == 0 {
+		job.enrich = nil
+	}
+
+	if batch {
+		results, err := runBatch(ctx, client, job, queries, *concurrency)
+		if err != nil {
+			return err
+		}
+		for _, br := range results {
+			if err := sinks.writeAll(ctx, newSnapshot(br.Query, br.Result)); err != nil {
+				return err
+			}
+			showNotes(ctx, br.Result.Items)
+		}
+		if err := writeBatch(os.Stdout, *outDir, *format, results, fields); err != nil {
+			return err
+		}
+		combined := combineResults(results)
+		writeSkipped(os.Stderr, combined.Items)
+		for _, br := range results {
+			warnPartial(br.Query, br.Result)
+		}
+		if len(combined.Items) == 0 {
+			return &noResultsError{}
+		}
+		return nil
+	}
+
+	// Chama nossa função
+	result, err := job.run(ctx, client, sf.query)
+	if err != nil {
+		return err
+	}
+	if err := sinks.writeAll(ctx, newSnapshot(sf.query, result)); err != nil {
+		return err
+	}
+	showNotes(ctx, result.Items)
+
+	if *lucky {
+		return runLucky(ctx, client, result, job.enrich, *format, *openURL)
+	}
+
+	// --- Aqui "tratamos os dados de resposta" ---
+	result.Meta = client.runMeta(result, start)
+	switch {
+	case *groupBy == "owner":
+		err = writeGroups(os.Stdout, *format, groupByOwner(result.Items))
+	case *cloud:
+		err = writeTopicCloud(os.Stdout, *format, topicCloud(result.Items, topicCloudSize))
+	case *cluster:
+		err = writeClusters(os.Stdout, *format, clusterRepos(result.Items, *clusterThreshold), fields)
+	default:
+		err = writeResults(os.Stdout, *format, result, fields)
+	}
+	if err != nil {
+		return err
+	}
+	// O rodapé vai junto da saída em text; em table, para o stderr, para
+	// que a tabela continue pronta para outros programas.
+	switch *format {
+	case formatText:
+		writeSummary(os.Stdout, result.Meta)
+	case formatTable:
+		writeSummary(os.Stderr, result.Meta)
+	}
+	writeSkipped(os.Stderr, result.Items)
+	warnPartial(sf.query, result)
+	if len(result.Items) == 0 {
+		return &noResultsError{query: sf.query}
+	}
+	if *starN > 0 || *unstarN > 0 {
+		return starTop(ctx, client, os.Stderr, result.Items, max(*starN, *unstarN), *unstarN > 0)
+	}
+	return nil
+}
+
+// runLucky mostra o perfil do primeiro resultado ou o abre no navegador.
+func runLucky(ctx context.Context, client *Client, result *SearchResult, applied []Stage, format string, open bool) error {
+	if len(result.Items) == 0 {
+		return &noResultsError{}
+	}
+	top := result.Items[0]
+	if open {
+		fmt.Printf(tr("Abrindo %s\n"), top.URL)
+		return openBrowser(top.URL)
+	}
+	p, err := fetchProfile(ctx, client, top, applied)
+	if err != nil {
+		return err
+	}
+	if err := writeProfile(os.Stdout, format, p); err != nil {
+		return err
+	}
+	writeSkipped(os.Stderr, []Repository{p.Repository})
+	return nil
+}
+
+// showNotes anexa aos repositórios as notas pessoais gravadas com `note`.
+// As notas são um complemento: sem elas a busca segue, só com um aviso.
+func showNotes(ctx context.Context, repos []Repository) {
+	path, err := defaultNotesPath()
+	if err == nil {
+		err = annotate(ctx, path, repos)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("aviso: notas indisponíveis: %v\n"), err)
+	}
+}
+
+// warnPartial avisa no stderr que o resultado da query está incompleto.
+func warnPartial(query string, result *SearchResult) {
+	if result.Partial != nil {
+		fmt.Fprintf(os.Stderr, tr("\naviso: query %q: %v\n"), query, result.Partial)
+	}
+}
+
+// countTrue conta quantas das condições são verdadeiras.
+func countTrue(conds ...bool) int {
+	n := 0
+	for _, c := range conds {
+		if c {
+			n++
+		}
+	}
+	return n
+}
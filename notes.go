@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Note é uma anotação pessoal sobre um repositório, guardada só na
+// máquina do usuário.
+type Note struct {
+	ID        int    `json:"id"`
+	FullName  string `json:"full_name"`
+	Text      string `json:"text"`
+	Tags      string `json:"tags"` // separadas por vírgula
+	CreatedAt string `json:"created_at"`
+}
+
+// notesSchema cria a tabela de notas no SQLite. full_name não diferencia
+// maiúsculas, como os nomes no GitHub.
+const notesSchema = `CREATE TABLE IF NOT EXISTS notes (
+	id         INTEGER PRIMARY KEY,
+	full_name  TEXT NOT NULL COLLATE NOCASE,
+	text       TEXT NOT NULL,
+	tags       TEXT NOT NULL DEFAULT '',
+	created_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS notes_repo ON notes (full_name);
+`
+
+// defaultNotesPath é o banco de notas padrão, no diretório de
+// configuração (ex: ~/.config/ghsearch/notes.db).
+func defaultNotesPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "notes.db"), nil
+}
+
+// addNote grava uma nota sobre fullName.
+func addNote(ctx context.Context, path, fullName, text string, tags []string) error {
+	sql := notesSchema + fmt.Sprintf("INSERT INTO notes (full_name, text, tags, created_at) VALUES (%s, %s, %s, %s);\n",
+		sqlQuote(fullName), sqlQuote(text), sqlQuote(strings.Join(tags, ",")), sqlQuote(time.Now().UTC().Format(time.RFC3339)))
+	return sqliteExec(ctx, path, sql)
+}
+
+// removeNotes apaga a nota id de fullName ou, com id 0, todas as notas
+// do repositório.
+func removeNotes(ctx context.Context, path, fullName string, id int) error {
+	where := "full_name = " + sqlQuote(fullName)
+	if id > 0 {
+		where += " AND id = " + sqlInt(id)
+	}
+	return sqliteExec(ctx, path, notesSchema+"DELETE FROM notes WHERE "+where+";\n")
+}
+
+// loadNotes lê as notas de fullNames (todas, se vazio), da mais antiga
+// para a mais nova.
+func loadNotes(ctx context.Context, path string, fullNames []string) ([]Note, error) {
+	sql := "SELECT id, full_name, text, tags, created_at FROM notes"
+	if len(fullNames) > 0 {
+		quoted := make([]string, len(fullNames))
+		for i, name := range fullNames {
+			quoted[i] = sqlQuote(name)
+		}
+		sql += " WHERE full_name IN (" + strings.Join(quoted, ", ") + ")"
+	}
+	var notes []Note
+	if err := sqliteQuery(ctx, path, notesSchema+sql+" ORDER BY full_name, id;\n", &notes); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// annotate preenche Notes nos repositórios que têm notas no banco em
+// path. Sem banco (ninguém anotou nada ainda) não há o que fazer, e o
+// sqlite3 nem é chamado.
+func annotate(ctx context.Context, path string, repos []Repository) error {
+	if len(repos) == 0 {
+		return nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	names := make([]string, len(repos))
+	for i, r := range repos {
+		names[i] = r.FullName
+	}
+	notes, err := loadNotes(ctx, path, names)
+	if err != nil {
+		return err
+	}
+	byRepo := map[string][]Note{}
+	for _, n := range notes {
+		key := strings.ToLower(n.FullName)
+		byRepo[key] = append(byRepo[key], n)
+	}
+	for i := range repos {
+		repos[i].Notes = byRepo[strings.ToLower(repos[i].FullName)]
+	}
+	return nil
+}
+
+// notesSummary junta as notas de um repositório numa linha, com as tags
+// como #tag.
+func notesSummary(notes []Note) string {
+	parts := make([]string, len(notes))
+	for i, n := range notes {
+		parts[i] = n.Text
+		for _, t := range strings.Split(n.Tags, ",") {
+			if t != "" {
+				parts[i] += " #" + t
+			}
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+func writeNotes(w io.Writer, notes []Note) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, tr("ID\tREPOSITÓRIO\tDATA\tTAGS\tNOTA"))
+	for _, n := range notes {
+		date, _, _ := strings.Cut(n.CreatedAt, "T")
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", n.ID, n.FullName, date, n.Tags, n.Text)
+	}
+	return tw.Flush()
+}
+
+// runNote implementa `note add|list|rm`: anotações pessoais sobre
+// repositórios, que aparecem junto deles nas buscas seguintes.
+func runNote(args []string) error {
+	usage := invalid(errors.New(tr("uso: note add owner/repo \"texto\" [-tag a,b] | note list [owner/repo] | note rm owner/repo [-id N]")))
+	if len(args) == 0 {
+		return usage
+	}
+	action, args := args[0], args[1:]
+
+	// Repositório e texto vêm antes das flags.
+	var positional []string
+	for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		positional, args = append(positional, args[0]), args[1:]
+	}
+	fs := newFlagSet("note " + action)
+	tags := fs.String("tag", "", "tags da nota, separadas por vírgula")
+	id := fs.Int("id", 0, "com rm, apaga só a nota com este id (padrão: todas do repositório)")
+	db := fs.String("db", "", "banco SQLite das notas (padrão: notes.db no diretório de configuração)")
+	fs.Parse(args)
+
+	if *db == "" {
+		path, err := defaultNotesPath()
+		if err != nil {
+			return err
+		}
+		*db = path
+	}
+
+	ctx := context.Background()
+	switch action {
+	case "add":
+		if len(positional) != 2 || !strings.Contains(positional[0], "/") || strings.TrimSpace(positional[1]) == "" {
+			return usage
+		}
+		if err := addNote(ctx, *db, positional[0], positional[1], parseTopics(*tags)); err != nil {
+			return err
+		}
+		fmt.Printf(tr("Nota adicionada a %s\n"), positional[0])
+		return nil
+	case "list":
+		if len(positional) > 1 {
+			return usage
+		}
+		if _, err := os.Stat(*db); errors.Is(err, os.ErrNotExist) {
+			fmt.Println(tr("Nenhuma nota ainda."))
+			return nil
+		}
+		notes, err := loadNotes(ctx, *db, positional)
+		if err != nil {
+			return err
+		}
+		return writeNotes(os.Stdout, notes)
+	case "rm":
+		if len(positional) != 1 || !strings.Contains(positional[0], "/") {
+			return usage
+		}
+		return removeNotes(ctx, *db, positional[0], *id)
+	}
+	return usage
+}
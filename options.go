@@ -0,0 +1,230 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Padrões do Client: seguros para quem não configura nada (sem token,
+// timeout curto, corpo limitado e sem repetição automática).
+const (
+	defaultTimeout      = 10 * time.Second
+	defaultRetryBackoff = time.Second
+)
+
+// ClientOption configura um Client em NewClient. As opções são aplicadas
+// na ordem recebida; a última de cada tipo prevalece.
+type ClientOption func(*Client) error
+
+// WithToken autentica as requisições com token. Vazio mantém o acesso
+// anônimo.
+func WithToken(token string) ClientOption {
+	return func(c *Client) error {
+		c.token = token
+		return nil
+	}
+}
+
+// WithBaseURL aponta o Client para outra raiz da API, como um GitHub
+// Enterprise (https://ghe.exemplo.com/api/v3) ou um servidor de testes.
+func WithBaseURL(base string) ClientOption {
+	return func(c *Client) error {
+		u, err := url.Parse(base)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf(tr("URL base inválida: %q"), base)
+		}
+		c.baseURL = strings.TrimSuffix(base, "/")
+		return nil
+	}
+}
+
+// WithTimeout limita cada tentativa de requisição HTTP, dos headers ao
+// fim do corpo; 0 remove o limite. O prazo vai no contexto da requisição,
+// então vale também com WithHTTPClient.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d < 0 {
+			return errors.New(tr("o timeout não pode ser negativo"))
+		}
+		c.timeout = d
+		return nil
+	}
+}
+
+// WithRetry repete até retries vezes as requisições GET que falham na rede
+// ou recebem 500, 502, 503 ou 504, com espera inicial backoff que dobra a
+// cada tentativa. Por padrão não há repetição.
+func WithRetry(retries int, backoff time.Duration) ClientOption {
+	return func(c *Client) error {
+		if retries < 0 || backoff < 0 {
+			return errors.New(tr("retries e backoff não podem ser negativos"))
+		}
+		c.retries = retries
+		c.retryBackoff = backoff
+		return nil
+	}
+}
+
+// WithCache guarda as respostas GET em disco, no diretório de cache do
+// usuário, por ttl; 0 desliga o cache. Resultados vazios e queries
+// inválidas (422) ficam por menos tempo (veja defaultCachePolicy).
+func WithCache(ttl time.Duration) ClientOption {
+	return WithCachePolicy(defaultCachePolicy(ttl))
+}
+
+// WithCachePolicy é WithCache com a validade de cada classe de resposta
+// escolhida uma a uma.
+func WithCachePolicy(p CachePolicy) ClientOption {
+	return func(c *Client) error {
+		if p.OK < 0 || p.Empty < 0 || p.Invalid < 0 {
+			return errors.New(tr("as validades do cache não podem ser negativas"))
+		}
+		c.cachePolicy = p
+		return nil
+	}
+}
+
+// WithCachePartition separa o cache de respostas deste Client num
+// subdiretório próprio, para que clientes com tokens diferentes (os
+// tenants do serve) nunca vejam as respostas uns dos outros.
+func WithCachePartition(name string) ClientOption {
+	return func(c *Client) error {
+		if strings.ContainsAny(name, `/\.`) {
+			return fmt.Errorf(tr("partição de cache inválida: %q"), name)
+		}
+		c.cachePartition = name
+		return nil
+	}
+}
+
+// WithRateLimiter controla o ritmo das chamadas a /search/* com l.
+func WithRateLimiter(l *rateLimiter) ClientOption {
+	return func(c *Client) error {
+		c.searchLimiter = l
+		return nil
+	}
+}
+
+// WithUserAgent troca o User-Agent enviado em todas as requisições.
+// Valores vazios são ignorados, pois a API exige o header.
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) error {
+		if ua != "" {
+			c.userAgent = ua
+		}
+		return nil
+	}
+}
+
+// WithMaxBodySize troca o limite de tamanho das respostas; n <= 0 remove
+// o limite.
+func WithMaxBodySize(n int64) ClientOption {
+	return func(c *Client) error {
+		c.maxBodySize = n
+		return nil
+	}
+}
+
+// Middleware envolve o transporte HTTP do Client, por exemplo para gravar
+// ou reproduzir respostas (-record/-replay) ou instrumentar as chamadas.
+type Middleware func(next http.RoundTripper) (http.RoundTripper, error)
+
+// WithMiddleware acrescenta m por fora do transporte: ele vê as respostas
+// já descomprimidas, inclusive as servidas pelo cache. Vários middlewares
+// são aplicados na ordem recebida, o último mais por fora.
+func WithMiddleware(m Middleware) ClientOption {
+	return func(c *Client) error {
+		c.middlewares = append(c.middlewares, m)
+		return nil
+	}
+}
+
+// WithHTTPClient injeta o cliente HTTP inteiro (fakes em testes, clientes
+// instrumentados). Nesse caso WithMiddleware e WithCache são ignorados:
+// quem injeta monta o próprio cliente.
+func WithHTTPClient(d Doer) ClientOption {
+	return func(c *Client) error {
+		c.httpClient = d
+		return nil
+	}
+}
+
+// buildHTTPClient monta o cliente HTTP padrão conforme as opções: a
+// descompressão por baixo do cache e os middlewares por fora. O timeout
+// não fica no http.Client: vai no contexto de cada tentativa (veja attempt).
+func (c *Client) buildHTTPClient() error {
+	if c.httpClient != nil {
+		return nil
+	}
+	var rt http.RoundTripper = &decodingTransport{next: http.DefaultTransport, maxBody: c.maxBodySize}
+	if c.cachePolicy.enabled() {
+		cached, err := newCachingTransport(c.cachePolicy, c.cachePartition, rt)
+		if err != nil {
+			return err
+		}
+		cached.identity = cacheIdentity(c.token)
+		rt = cached
+		c.cache = cached
+	}
+	for _, m := range c.middlewares {
+		next, err := m(rt)
+		if err != nil {
+			return err
+		}
+		rt = next
+	}
+	c.httpClient = &http.Client{Transport: rt}
+	return nil
+}
+
+// retryable informa se vale repetir a requisição: só GET (idempotente) e
+// só em falhas de rede ou erros temporários do servidor.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Method != http.MethodGet || req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// doWithRetry executa a requisição, repetindo-a conforme WithRetry. Cada
+// tentativa tem o próprio prazo (WithTimeout), e uma tentativa que estoura
+// o prazo conta como falha de rede, que pode ser repetida.
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	delay := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		try, cancel := c.attempt(req)
+		resp, err := c.httpClient.Do(try)
+		err = c.timeoutError(req.Context(), err)
+		if attempt == c.retries || !retryable(req, resp, err) {
+			if resp == nil {
+				cancel()
+				return nil, err
+			}
+			resp.Body = cancelOnClose{resp.Body, cancel}
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		cancel()
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// field é uma coluna selecionável com -fields.
+type field struct {
+	Name  string
+	Value func(r Repository) any
+}
+
+// repoFields lista, em ordem, todas as colunas disponíveis.
+var repoFields = []field{
+	{"name", func(r Repository) any { return r.Name }},
+	{"full_name", func(r Repository) any { return r.FullName }},
+	{"owner", func(r Repository) any { return r.Owner.Login }},
+	{"owner_type", func(r Repository) any { return r.Owner.Type }},
+	{"owner_avatar", func(r Repository) any { return r.Owner.AvatarURL }},
+	{"description", func(r Repository) any { return r.Description }},
+	{"url", func(r Repository) any { return r.URL }},
+	{"language", func(r Repository) any { return r.Language }},
+	{"stars", func(r Repository) any { return r.Stars }},
+	{"forks", func(r Repository) any { return r.Forks }},
+	{"watchers", func(r Repository) any { return r.Watchers }},
+	{"open_issues", func(r Repository) any { return r.OpenIssues }},
+	{"size", func(r Repository) any { return r.Size }},
+	{"default_branch", func(r Repository) any { return r.DefaultBranch }},
+	{"created_at", func(r Repository) any { return r.CreatedAt }},
+	{"updated_at", func(r Repository) any { return r.UpdatedAt }},
+	{"pushed_at", func(r Repository) any { return r.PushedAt }},
+	{"topics", func(r Repository) any { return r.Topics }},
+	{"archived", func(r Repository) any { return r.Archived }},
+	{"score", func(r Repository) any { return r.Score }},
+	{"last_commit_at", func(r Repository) any {
+		if r.unavailable("activity") {
+			return unavailableValue
+		}
+		if r.LastCommitAt == nil {
+			return time.Time{}
+		}
+		return *r.LastCommitAt
+	}},
+	{"health", func(r Repository) any { return healthScore(r, time.Now()) }},
+	{"velocity", func(r Repository) any { return starVelocity(r, time.Now()) }},
+	{"ci", func(r Repository) any {
+		if r.unavailable("ci") {
+			return unavailableValue
+		}
+		return r.CIStatus
+	}},
+	{"security", func(r Repository) any { return securitySummary(r) }},
+	{"commit_activity", func(r Repository) any { return heatmapSummary(r) }},
+	{"languages", func(r Repository) any { return languagesSummary(r) }},
+	{"release", func(r Repository) any { return releaseSummary(r) }},
+	{"notes", func(r Repository) any { return notesSummary(r.Notes) }},
+	{"starred_at", func(r Repository) any {
+		if r.StarredAt == nil {
+			return time.Time{}
+		}
+		return *r.StarredAt
+	}},
+}
+
+// defaultFields são as colunas usadas quando -fields não é informado.
+const defaultFields = "full_name,stars,forks,url,description"
+
+// parseFields converte "stars,forks,full_name" na lista de colunas.
+func parseFields(spec string) ([]field, error) {
+	var fields []field
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		f, ok := lookupField(name)
+		if !ok {
+			return nil, invalid(fmt.Errorf(tr("campo desconhecido em -fields: %q (disponíveis: %s)"), name, fieldNames()))
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, invalid(errors.New(tr("-fields não pode ser vazio")))
+	}
+	return fields, nil
+}
+
+func lookupField(name string) (field, bool) {
+	for _, f := range repoFields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+func fieldNames() string {
+	names := make([]string, len(repoFields))
+	for i, f := range repoFields {
+		names[i] = f.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatValue converte um valor de coluna em texto para tabela e CSV.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, ",")
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	}
+	return fmt.Sprint(v)
+}
+
+// Formatos de saída aceitos por -format.
+const (
+	formatText  = "text"
+	formatTable = "table"
+	formatCSV   = "csv"
+	formatJSON  = "json"
+)
+
+// writeResults escreve o resultado no formato escolhido. O formato text
+// mantém o layout original e ignora -fields.
+func writeResults(w io.Writer, format string, result *SearchResult, fields []field) error {
+	switch format {
+	case formatText:
+		return writeText(w, result)
+	case formatTable:
+		return writeTable(w, result.Items, fields)
+	case formatCSV:
+		return writeCSV(w, result.Items, fields)
+	case formatJSON:
+		return writeJSON(w, result, fields)
+	}
+	return fmt.Errorf(tr("formato desconhecido: %q (use text, table, csv ou json)"), format)
+}
+
+func writeText(w io.Writer, result *SearchResult) error {
+	fmt.Fprintf(w, tr("Encontrados %d repositórios. Mostrando os %d primeiros:\n"), result.TotalCount, len(result.Items))
+	fmt.Fprintln(w, "---------------------------------------------------------")
+
+	// Itera sobre os items (repositórios) retornados
+	for i, repo := range result.Items {
+		fmt.Fprintf(w, "#%d: %s\n", i+1, repo.FullName)
+		fmt.Fprintf(w, tr("   ⭐ Estrelas: %d\n"), repo.Stars)
+		fmt.Fprintf(w, "   🍴 Forks:    %d\n", repo.Forks)
+		fmt.Fprintf(w, "   🔗 URL:       %s\n", repo.URL)
+		if langs := languagesSummary(repo); langs != "" {
+			fmt.Fprintf(w, tr("   💬 Linguagens: %s\n"), langs)
+		}
+		if rel := releaseSummary(repo); rel != "" {
+			fmt.Fprintf(w, "   📦 Release:   %s\n", rel)
+		}
+		ci := repo.CIStatus
+		if repo.unavailable("ci") {
+			ci = unavailableValue
+		}
+		if ci != "" {
+			fmt.Fprintf(w, "   🚦 CI:        %s\n", ci)
+		}
+		if sec := securitySummary(repo); sec != "" {
+			fmt.Fprintf(w, tr("   🛡  Segurança: %s\n"), sec)
+		}
+		if heat := heatmapSummary(repo); heat != "" {
+			fmt.Fprintf(w, tr("   📈 Commits:   %s\n"), heat)
+		}
+		if len(repo.Notes) > 0 {
+			fmt.Fprintf(w, tr("   📝 Notas:     %s\n"), notesSummary(repo.Notes))
+		}
+		fmt.Fprintf(w, "   %s\n\n", repo.Description)
+	}
+	return nil
+}
+
+func writeTable(w io.Writer, repos []Repository, fields []field) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(fields))
+	for i, f := range fields {
+		headers[i] = fieldLabel(f.Name)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, repo := range repos {
+		cells := make([]string, len(fields))
+		for i, f := range fields {
+			cells[i] = formatValue(f.Value(repo))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+func writeCSV(w io.Writer, repos []Repository, fields []field) error {
+	cw := csv.NewWriter(w)
+	headers := make([]string, len(fields))
+	for i, f := range fields {
+		headers[i] = f.Name
+	}
+	if err := cw.Write(headers); err != nil {
+		return fmt.Errorf(tr("falha ao escrever CSV: %w"), err)
+	}
+
+	for _, repo := range repos {
+		cells := make([]string, len(fields))
+		for i, f := range fields {
+			cells[i] = formatValue(f.Value(repo))
+		}
+		if err := cw.Write(cells); err != nil {
+			return fmt.Errorf(tr("falha ao escrever CSV: %w"), err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// row é um item JSON com as colunas na ordem pedida em -fields
+// (um map ordenaria as chaves alfabeticamente).
+type row struct {
+	fields []field
+	repo   Repository
+}
+
+func (r row) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, f := range r.fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.Name)
+		val, err := json.Marshal(f.Value(r.repo))
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(val)
+	}
+	b.WriteByte('}')
+	return []byte(b.String()), nil
+}
+
+// jsonResult é o formato JSON de um resultado, só com as colunas pedidas.
+type jsonResult struct {
+	TotalCount int      `json:"total_count"`
+	Items      []row    `json:"items"`
+	Error      string   `json:"error,omitempty"` // resultados parciais
+	Meta       *runMeta `json:"meta,omitempty"`
+}
+
+func newJSONResult(result *SearchResult, fields []field) jsonResult {
+	rows := make([]row, len(result.Items))
+	for i, repo := range result.Items {
+		rows[i] = row{fields: fields, repo: repo}
+	}
+	out := jsonResult{TotalCount: result.TotalCount, Items: rows, Meta: result.Meta}
+	if result.Partial != nil {
+		out.Error = result.Partial.Error()
+	}
+	return out
+}
+
+func writeJSON(w io.Writer, result *SearchResult, fields []field) error {
+	return encodeJSON(w, newJSONResult(result, fields))
+}
+
+// encodeJSON escreve v como JSON indentado.
+func encodeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf(tr("falha ao codificar JSON: %w"), err)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// O processamento de uma busca é um pipeline: fetch (as páginas da busca)
+// → enriquecimentos por repositório (languages, release, ci...) → score
+// (o Ranker) → sink. Fetch e os enriquecimentos rodam ao mesmo tempo (veja
+// searchPipeline): cada página que chega já segue para a primeira etapa,
+// e enquanto uma etapa ainda processa um repositório, a seguinte já
+// trabalha nos que ela liberou. O score e o sink precisam do resultado
+// inteiro e rodam depois, sobre os repositórios que saíram do pipeline.
+
+// Stage é uma etapa de enriquecimento. Para criar um enriquecimento novo
+// basta implementar Stage e registrá-lo em enrichers.
+type Stage interface {
+	// Name é o nome aceito em -enrich e usado em Repository.Unavailable.
+	Name() string
+	// Calls é o número de chamadas à API por repositório, usado pelo planner.
+	Calls() int
+	// Workers é quantos repositórios a etapa processa em paralelo.
+	Workers() int
+	// Process completa r. Um erro marca a etapa como indisponível para r,
+	// sem interromper o pipeline.
+	Process(ctx context.Context, c *Client, r *Repository) error
+}
+
+// defaultStageWorkers é o paralelismo das etapas que não pedem outro.
+const defaultStageWorkers = 4
+
+// funcStage adapta uma função de enriquecimento a Stage.
+type funcStage struct {
+	name    string
+	calls   int
+	workers int // 0 usa defaultStageWorkers
+	fn      func(ctx context.Context, c *Client, r *Repository) error
+}
+
+func (s funcStage) Name() string { return s.name }
+func (s funcStage) Calls() int   { return s.calls }
+
+func (s funcStage) Workers() int {
+	if s.workers > 0 {
+		return s.workers
+	}
+	return defaultStageWorkers
+}
+
+func (s funcStage) Process(ctx context.Context, c *Client, r *Repository) error {
+	return s.fn(ctx, c, r)
+}
+
+// runPipeline passa repositórios já buscados pelas etapas, na ordem de
+// stages. Uma falha (404, 451 por DMCA, quota esgotada...) não interrompe
+// a execução: a etapa fica marcada como indisponível no repositório, com o
+// motivo, e ele segue para as próximas. Só o cancelamento de ctx é
+// devolvido como erro.
+func runPipeline(ctx context.Context, client *Client, repos []Repository, stages []Stage) error {
+	if len(stages) == 0 || len(repos) == 0 {
+		return nil
+	}
+
+	prog := client.progressFor(ctx)
+	prog.Start(tr("enriquecimento"), len(repos), tr("repositórios"))
+	defer prog.Finish()
+
+	source := make(chan *Repository)
+	out := runStages(ctx, client, stages, source)
+	go func() {
+		defer close(source)
+		for i := range repos {
+			select {
+			case source <- &repos[i]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for range out {
+		prog.Step(0)
+	}
+	return ctx.Err()
+}
+
+// searchPipeline é o pipeline completo de uma busca: a busca é a etapa de
+// origem, e os repositórios de cada página que passam em keep entram nos
+// enriquecimentos assim que a página chega, enquanto as seguintes ainda
+// estão sendo buscadas. Como a saída de cada etapa é limitada, uma etapa
+// lenta segura as anteriores até a própria busca, que para de pedir
+// páginas até haver espaço. O resultado mantém a ordem da API; erros da
+// busca seguem como em SearchAllRepositories (com *PartialError depois
+// da primeira página).
+func searchPipeline(ctx context.Context, client *Client, opts SearchOptions, limit int, keep func([]Repository) []Repository, stages []Stage) (*SearchResult, error) {
+	if len(stages) == 0 {
+		result, err := client.SearchAllRepositories(ctx, opts, limit)
+		if result != nil {
+			result.Items = keep(result.Items)
+		}
+		return result, err
+	}
+
+	var (
+		fetched []*Repository // só a origem acrescenta, até fechar fetchDone
+		total   int
+		err     error
+	)
+	source := make(chan *Repository)
+	fetchDone := make(chan struct{})
+	out := runStages(ctx, client, stages, source)
+	go func() {
+		defer close(source)
+		defer close(fetchDone)
+		total, _, err = collectEach(ctx, client.RepositoryIterator(opts), limit, func(items []Repository) {
+			for _, r := range keep(items) {
+				fetched = append(fetched, &r)
+				select {
+				case source <- &r:
+				case <-ctx.Done():
+				}
+			}
+		})
+	}()
+
+	// A barra mostra a busca enquanto ela dura e depois os repositórios
+	// que ainda faltam enriquecer.
+	prog := client.progressFor(ctx)
+	done, enriching := 0, false
+	for out != nil {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				out = nil
+				continue
+			}
+			done++
+			if enriching {
+				prog.Step(0)
+			}
+		case <-fetchDone:
+			fetchDone = nil
+			if pending := len(fetched) - done; pending > 0 {
+				prog.Start(tr("enriquecimento"), pending, tr("repositórios"))
+				enriching = true
+			}
+		}
+	}
+	if enriching {
+		prog.Finish()
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var partial *PartialError
+	if err != nil && !errors.As(err, &partial) {
+		return nil, err
+	}
+	items := make([]Repository, len(fetched))
+	for i, r := range fetched {
+		items[i] = *r
+	}
+	return &SearchResult{TotalCount: total, Items: items, Partial: partial}, err
+}
+
+// runStages encadeia as etapas a partir de in e devolve a saída da última.
+func runStages(ctx context.Context, client *Client, stages []Stage, in <-chan *Repository) <-chan *Repository {
+	for _, s := range stages {
+		in = runStage(ctx, client, s, in)
+	}
+	return in
+}
+
+// runStage inicia os workers de s, que leem de in os repositórios e os
+// entregam, já processados, no canal devolvido. A saída só tem espaço
+// para um repositório por worker: se a etapa seguinte for mais lenta,
+// esta para de ler e a anterior espera, em vez de acumular repositórios
+// (e chamadas à API) à frente de quem não consegue acompanhar. Depois do
+// cancelamento de ctx, os repositórios só passam adiante, sem chamadas,
+// para que o pipeline termine.
+func runStage(ctx context.Context, client *Client, s Stage, in <-chan *Repository) <-chan *Repository {
+	workers := s.Workers()
+	out := make(chan *Repository, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range in {
+				if ctx.Err() == nil {
+					stageCtx, cancel := withTimeout(ctx, client.enrichTimeout)
+					if err := s.Process(stageCtx, client, r); err != nil && ctx.Err() == nil {
+						r.markUnavailable(s.Name(), err)
+					}
+					cancel()
+				}
+				out <- r
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
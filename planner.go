@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+// runPlan descreve o trabalho que uma execução pretende fazer, para que
+// possamos estimar as chamadas à API antes de começar.
+type runPlan struct {
+	Queries int  // quantidade de queries (lote); 0 equivale a 1
+	Limit   int  // total de resultados desejados por query
+	PerPage int  // resultados por página de busca
+	Enrich  int  // chamadas extras (enriquecimento) por repositório
+	Sample  bool // Limit é o tamanho de uma amostra (-sample), não os primeiros
+}
+
+// queries devolve o número de queries, tratando o valor zero como uma.
+func (p runPlan) queries() int {
+	return max(p.Queries, 1)
+}
+
+// SearchCalls estima quantas páginas de busca serão necessárias.
+func (p runPlan) SearchCalls() int {
+	if p.Limit <= 0 || p.PerPage <= 0 {
+		return 0
+	}
+	if p.Sample {
+		// A primeira página, mais uma por posição sorteada, no pior caso.
+		pages := (maxSearchResults + p.PerPage - 1) / p.PerPage
+		return p.queries() * min(1+p.Limit, pages)
+	}
+	return p.queries() * ((p.Limit + p.PerPage - 1) / p.PerPage)
+}
+
+// CoreCalls estima as chamadas de enriquecimento, que consomem a quota core.
+func (p runPlan) CoreCalls() int {
+	return p.queries() * p.Limit * p.Enrich
+}
+
+// Total soma todas as chamadas previstas.
+func (p runPlan) Total() int {
+	return p.SearchCalls() + p.CoreCalls()
+}
+
+// Modos de reação quando o plano não cabe no orçamento.
+const (
+	budgetWarn      = "warn"
+	budgetPrompt    = "prompt"
+	budgetDownscale = "downscale"
+)
+
+// budgetPolicy é o orçamento definido pelo usuário (-budget/-budget-mode).
+// Max <= 0 significa "sem orçamento próprio"; a quota restante ainda vale.
+type budgetPolicy struct {
+	Max  int
+	Mode string
+	In   io.Reader // de onde ler a resposta no modo prompt
+}
+
+// fits informa se o plano cabe na quota restante e no orçamento.
+func (p runPlan) fits(limits *RateLimits, budget budgetPolicy) bool {
+	if p.SearchCalls() > limits.Resources.Search.Remaining {
+		return false
+	}
+	if p.CoreCalls() > limits.Resources.Core.Remaining {
+		return false
+	}
+	return budget.Max <= 0 || p.Total() <= budget.Max
+}
+
+// fitPlan confronta o plano com a quota e o orçamento e, conforme o modo,
+// avisa, pergunta ao usuário ou reduz -limit/-enrich até caber.
+func fitPlan(plan runPlan, limits *RateLimits, budget budgetPolicy) (runPlan, error) {
+	if plan.fits(limits, budget) {
+		return plan, nil
+	}
+
+	summary := fmt.Sprintf(tr("a execução prevê %d chamadas (%d de busca, %d de enriquecimento); quota restante: %d de busca, %d core"),
+		plan.Total(), plan.SearchCalls(), plan.CoreCalls(),
+		limits.Resources.Search.Remaining, limits.Resources.Core.Remaining)
+	if budget.Max > 0 {
+		summary += fmt.Sprintf(tr("; orçamento: %d"), budget.Max)
+	}
+
+	switch budget.Mode {
+	case budgetWarn, "":
+		log.Printf(tr("AVISO: %s"), summary)
+		return plan, nil
+	case budgetPrompt:
+		fmt.Printf(tr("%s.\nContinuar mesmo assim? [s/N] "), summary)
+		answer, _ := bufio.NewReader(budget.In).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a == "s" || a == "y" {
+			return plan, nil
+		}
+		return plan, errors.New(tr("execução cancelada pelo usuário"))
+	case budgetDownscale:
+		return downscale(plan, limits, budget, summary)
+	}
+	return plan, fmt.Errorf(tr("modo de orçamento desconhecido: %q (use warn, prompt ou downscale)"), budget.Mode)
+}
+
+// downscale reduz primeiro o número de resultados e, se nem um único
+// resultado enriquecido couber, desliga o enriquecimento.
+func downscale(plan runPlan, limits *RateLimits, budget budgetPolicy, summary string) (runPlan, error) {
+	original := plan
+	for plan.Limit > 1 && !plan.fits(limits, budget) {
+		plan.Limit--
+	}
+	if !plan.fits(limits, budget) && plan.Enrich > 0 {
+		plan.Enrich = 0
+		plan.Limit = original.Limit
+		for plan.Limit > 1 && !plan.fits(limits, budget) {
+			plan.Limit--
+		}
+	}
+	if !plan.fits(limits, budget) {
+		return plan, fmt.Errorf(tr("nem o plano mínimo cabe no orçamento: %s"), summary)
+	}
+	log.Printf(tr("AVISO: %s; reduzindo para -limit %d e -enrich %d"), summary, plan.Limit, plan.Enrich)
+	return plan, nil
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// credential é um token do pool (vazio para a quota anônima) e a última
+// quota observada em cada grupo de endpoints.
+type credential struct {
+	token  string
+	core   RateBucket
+	search RateBucket
+}
+
+// label identifica a credencial em logs e erros sem expor o token.
+func (c *credential) label() string {
+	if c.token == "" {
+		return tr("anônimo")
+	}
+	return "…" + c.token[max(0, len(c.token)-4):]
+}
+
+// tokenPool distribui as requisições entre vários tokens (e, opcionalmente,
+// a quota anônima), escolhendo a cada requisição a credencial com mais
+// quota restante no grupo do endpoint. É seguro para uso concorrente.
+type tokenPool struct {
+	mu    sync.Mutex
+	creds []*credential
+}
+
+// newTokenPool cria o pool. Tokens repetidos são ignorados; anonymous
+// inclui a quota anônima, usada por último por ser a menor.
+func newTokenPool(tokens []string, anonymous bool) *tokenPool {
+	p := &tokenPool{}
+	seen := map[string]bool{}
+	for _, t := range tokens {
+		if t = strings.TrimSpace(t); t != "" && !seen[t] {
+			seen[t] = true
+			p.creds = append(p.creds, &credential{token: t})
+		}
+	}
+	if anonymous {
+		p.creds = append(p.creds, &credential{})
+	}
+	return p
+}
+
+// Len devolve a quantidade de credenciais no pool.
+func (p *tokenPool) Len() int {
+	return len(p.creds)
+}
+
+// authenticated conta as credenciais com token.
+func (p *tokenPool) authenticated() int {
+	n := 0
+	for _, c := range p.creds {
+		if c.token != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// bucket devolve o grupo de quota da credencial para o caminho da API.
+func (c *credential) bucket(path string) *RateBucket {
+	if strings.Contains(path, "/search/") {
+		return &c.search
+	}
+	return &c.core
+}
+
+// pick escolhe a credencial para uma requisição ao caminho path: a de
+// maior quota restante entre as que ainda têm quota (credenciais nunca
+// usadas contam como cheias). A escolha já desconta uma unidade, para que
+// workers concorrentes se espalhem entre os tokens. Sem nenhuma com quota,
+// fica a de reset mais próximo; o rateLimiter do Client segura a espera.
+func (p *tokenPool) pick(path string, now time.Time) *credential {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var best, soonest *credential
+	bestScore := -1
+	for _, c := range p.creds {
+		b := c.bucket(path)
+		known := b.Limit > 0 && now.Before(b.ResetTime())
+		score := b.Remaining
+		if !known {
+			// Quota desconhecida ou já renovada: prioriza tokens autenticados.
+			score = 1 << 20
+			if c.token == "" {
+				score = 1 << 10
+			}
+		}
+		if score > 0 && score > bestScore {
+			best, bestScore = c, score
+		}
+		if soonest == nil || b.Reset < soonest.bucket(path).Reset {
+			soonest = c
+		}
+	}
+	if best == nil {
+		return soonest
+	}
+	if b := best.bucket(path); b.Remaining > 0 {
+		b.Remaining--
+	}
+	return best
+}
+
+// observe registra a quota informada pelos headers de uma resposta e
+// devolve a quota agregada do grupo: esgotada só quando todas as
+// credenciais estão, com o reset mais próximo entre elas.
+func (p *tokenPool) observe(c *credential, path string, rate RateBucket, now time.Time) RateBucket {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if rate.Limit > 0 {
+		*c.bucket(path) = rate
+	}
+	agg := RateBucket{}
+	for _, c := range p.creds {
+		b := c.bucket(path)
+		if b.Limit == 0 || !now.Before(b.ResetTime()) {
+			// Sem informação ou já renovada: o grupo ainda tem quota.
+			return RateBucket{}
+		}
+		agg.Limit += b.Limit
+		agg.Remaining += b.Remaining
+		agg.Used += b.Used
+		if agg.Reset == 0 || b.Reset < agg.Reset {
+			agg.Reset = b.Reset
+		}
+	}
+	return agg
+}
+
+// credentialKey é a chave de contexto que força uma credencial do pool.
+type credentialKey struct{}
+
+// withCredential faz as requisições feitas com ctx usarem c, em vez da
+// escolha automática do pool.
+func withCredential(ctx context.Context, c *credential) context.Context {
+	return context.WithValue(ctx, credentialKey{}, c)
+}
+
+// authorize escolhe a credencial da requisição e ajusta o header
+// Authorization de acordo.
+func (p *tokenPool) authorize(req *http.Request) *credential {
+	c, _ := req.Context().Value(credentialKey{}).(*credential)
+	if c == nil {
+		c = p.pick(req.URL.Path, time.Now())
+	}
+	if c.token == "" {
+		req.Header.Del("Authorization")
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return c
+}
+
+// SetTokenPool passa a distribuir as requisições entre as credenciais do
+// pool, no lugar do token único do Client.
+func (c *Client) SetTokenPool(p *tokenPool) {
+	c.pool = p
+	if len(p.creds) > 0 {
+		c.token = p.creds[0].token
+	}
+	if c.cache != nil {
+		tokens := make([]string, len(p.creds))
+		for i, cred := range p.creds {
+			tokens[i] = cred.token
+		}
+		c.cache.identity = cacheIdentity(tokens...)
+	}
+}
+
+// poolRateLimits consulta /rate_limit com cada credencial do pool (o que
+// também inicializa a quota conhecida de cada uma) e devolve a soma.
+// Tokens rejeitados pelo GitHub são erro.
+func (c *Client) poolRateLimits(ctx context.Context) (*RateLimits, error) {
+	total := &RateLimits{}
+	for _, cred := range c.pool.creds {
+		limits, err := c.RateLimit(withCredential(ctx, cred))
+		if err != nil {
+			return nil, fmt.Errorf(tr("token %s do pool: %w"), cred.label(), err)
+		}
+		c.pool.mu.Lock()
+		cred.core, cred.search = limits.Resources.Core, limits.Resources.Search
+		c.pool.mu.Unlock()
+
+		for _, pair := range []struct{ dst, src *RateBucket }{
+			{&total.Resources.Core, &limits.Resources.Core},
+			{&total.Resources.Search, &limits.Resources.Search},
+		} {
+			pair.dst.Limit += pair.src.Limit
+			pair.dst.Remaining += pair.src.Remaining
+			pair.dst.Used += pair.src.Used
+			if pair.dst.Reset == 0 || pair.src.Reset < pair.dst.Reset {
+				pair.dst.Reset = pair.src.Reset
+			}
+		}
+	}
+	return total, nil
+}
+
+// readTokens lê tokens de GITHUB_TOKENS (separados por vírgula) e, se path
+// não for vazio, de um arquivo com um token por linha (# comenta).
+func readTokens(path string) ([]string, error) {
+	var tokens []string
+	for _, t := range strings.Split(os.Getenv("GITHUB_TOKENS"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	if path == "" {
+		return tokens, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf(tr("falha ao abrir arquivo de tokens: %w"), err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			tokens = append(tokens, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf(tr("falha ao ler arquivo de tokens: %w"), err)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// authReport resume o estado da autenticação e da quota.
+type authReport struct {
+	Authenticated bool
+	User          *User
+	Scopes        []string
+	Limits        *RateLimits
+}
+
+// checkAuth consulta /rate_limit e, havendo token, /user. Um token
+// rejeitado pelo GitHub (401) é sempre tratado como erro.
+func checkAuth(ctx context.Context, client *Client) (*authReport, error) {
+	report := &authReport{Authenticated: client.token != ""}
+
+	userCtx := ctx
+	if client.pool != nil {
+		report.Authenticated = client.pool.authenticated() > 0
+		userCtx = withCredential(ctx, client.pool.creds[0])
+	}
+
+	if report.Authenticated {
+		user, scopes, err := client.CurrentUser(userCtx)
+		if err != nil {
+			return nil, fmt.Errorf(tr("token inválido ou sem acesso a /user: %w"), err)
+		}
+		report.User, report.Scopes = user, scopes
+	}
+
+	// Com pool, a quota é a soma das credenciais.
+	limits, err := client.RateLimit(ctx)
+	if client.pool != nil {
+		limits, err = client.poolRateLimits(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	report.Limits = limits
+	return report, nil
+}
+
+// preflight roda antes da busca: rejeita tokens inválidos e confronta o
+// plano da execução com a quota restante e o orçamento do usuário,
+// devolvendo o plano (possivelmente reduzido) a ser executado.
+func preflight(ctx context.Context, client *Client, plan runPlan, budget budgetPolicy) (runPlan, error) {
+	report, err := checkAuth(ctx, client)
+	if err != nil {
+		return plan, err
+	}
+	return fitPlan(plan, report.Limits, budget)
+}
+
+// runAuth implementa o subcomando `auth` e seus filhos.
+func runAuth(args []string) error {
+	if len(args) == 0 || args[0] != "status" {
+		return errors.New(tr("uso: auth status"))
+	}
+	fs := newFlagSet("auth status")
+	cf := addClientFlags(fs)
+	fs.Parse(args[1:])
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := cf.context()
+	defer cancel()
+	report, err := checkAuth(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if report.Authenticated {
+		fmt.Printf(tr("Autenticado como: %s\n"), report.User.Login)
+		if len(report.Scopes) > 0 {
+			fmt.Printf(tr("Escopos:          %v\n"), report.Scopes)
+		} else {
+			fmt.Println(tr("Escopos:          (nenhum informado; token fine-grained ou sem escopos)"))
+		}
+	} else {
+		fmt.Println(tr("Sem token: as chamadas serão anônimas."))
+	}
+
+	core, search := report.Limits.Resources.Core, report.Limits.Resources.Search
+	fmt.Printf(tr("Quota core:       %d/%d (renova às %s)\n"), core.Remaining, core.Limit, core.ResetTime().Format(time.Kitchen))
+	fmt.Printf(tr("Quota search:     %d/%d (renova às %s)\n"), search.Remaining, search.Limit, search.ResetTime().Format(time.Kitchen))
+	return nil
+}
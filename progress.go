@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// progressInterval é a frequência das linhas de status quando a saída de
+// erro não é um terminal (ex: redirecionada para arquivo).
+const progressInterval = 5 * time.Second
+
+// progress mostra no stderr o andamento de execuções longas: páginas
+// buscadas, itens coletados, ETA, quota restante e esperas pelo limite.
+// Em terminal, a linha é reescrita no lugar. Um *progress nil não faz
+// nada, então os chamadores não precisam checar. É seguro para uso
+// concorrente.
+type progress struct {
+	mu    sync.Mutex
+	w     io.Writer
+	tty   bool
+	phase string
+	unit  string
+	done  int
+	total int
+	items int
+	rate  RateBucket
+	wait  time.Time // esperando o limitador até este instante
+	start time.Time
+	shown time.Time // última linha escrita
+}
+
+// newProgress cria o indicador em f, detectando se f é um terminal.
+func newProgress(f *os.File) *progress {
+	p := &progress{w: f}
+	if info, err := f.Stat(); err == nil {
+		p.tty = info.Mode()&os.ModeCharDevice != 0
+	}
+	return p
+}
+
+// Start inicia uma fase (ex: "busca", 10 "páginas"). total 0 significa
+// ainda desconhecido.
+func (p *progress) Start(phase string, total int, unit string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.phase, p.unit, p.total, p.done, p.items = phase, unit, total, 0, 0
+	p.start = time.Now()
+	p.wait = time.Time{}
+	p.render(true)
+}
+
+// SetTotal atualiza o total da fase, quando ele só é conhecido depois da
+// primeira resposta (ex: total de páginas da busca).
+func (p *progress) SetTotal(total int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total = total
+}
+
+// Step registra mais uma unidade concluída e os itens que ela trouxe.
+func (p *progress) Step(items int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	p.items += items
+	p.wait = time.Time{}
+	p.render(false)
+}
+
+// Rate registra a quota informada pela última resposta.
+func (p *progress) Rate(b RateBucket) {
+	if p == nil || b.Limit == 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rate = b
+}
+
+// Waiting avisa que a próxima requisição está segura pelo limitador até
+// until, para que a espera não pareça um travamento.
+func (p *progress) Waiting(until time.Time) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.wait = until
+	p.render(true)
+}
+
+// Finish encerra a fase, deixando a última linha visível.
+func (p *progress) Finish() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.phase == "" {
+		return
+	}
+	p.render(true)
+	if p.tty {
+		fmt.Fprintln(p.w)
+	}
+	p.phase = ""
+}
+
+// render escreve a linha de status; fora de terminal, só a cada
+// progressInterval (ou quando force).
+func (p *progress) render(force bool) {
+	if p.phase == "" {
+		return
+	}
+	now := time.Now()
+	if !p.tty && !force && now.Sub(p.shown) < progressInterval {
+		return
+	}
+	p.shown = now
+
+	var parts []string
+	if p.total > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d %s", p.done, p.total, p.unit))
+	} else {
+		parts = append(parts, fmt.Sprintf("%d %s", p.done, p.unit))
+	}
+	if p.items > 0 {
+		parts = append(parts, fmt.Sprintf(tr("%d itens"), p.items))
+	}
+	if p.total > 0 && p.done > 0 && p.done < p.total {
+		eta := time.Duration(float64(now.Sub(p.start)) / float64(p.done) * float64(p.total-p.done))
+		parts = append(parts, "ETA "+eta.Round(time.Second).String())
+	}
+	if p.rate.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("quota %d/%d", p.rate.Remaining, p.rate.Limit))
+	}
+	if wait := time.Until(p.wait); wait > 0 {
+		parts = append(parts, tr("aguardando limite por ")+wait.Round(time.Second).String())
+	}
+
+	line := p.phase + ": " + strings.Join(parts, " · ")
+	if p.tty {
+		fmt.Fprintf(p.w, "\r\033[K%s", line)
+	} else {
+		fmt.Fprintln(p.w, line)
+	}
+}
+
+// quietProgress descarta o andamento; serve para -quiet, que também cala
+// o log de cada requisição.
+func quietProgress() *progress {
+	return &progress{w: io.Discard}
+}
+
+// progressKey marca um contexto cujas buscas não mexem no indicador.
+type progressKey struct{}
+
+// withoutProgress devolve ctx marcado para que as buscas e enriquecimentos
+// feitos com ele não abram fases próprias no indicador: quem chama já
+// mostra a sua (uma consulta por repositório, um lote de queries), que
+// de outra forma seria sobrescrita a cada busca.
+func withoutProgress(ctx context.Context) context.Context {
+	return context.WithValue(ctx, progressKey{}, true)
+}
+
+// progressFor devolve o indicador para as fases abertas com ctx: nil
+// (nada é mostrado) se ctx veio de withoutProgress.
+func (c *Client) progressFor(ctx context.Context) *progress {
+	if off, _ := ctx.Value(progressKey{}).(bool); off {
+		return nil
+	}
+	return c.progress
+}
+
+// newProgressFlag cria o indicador conforme -quiet: no stderr, ou
+// descartado.
+func newProgressFlag(quiet bool) *progress {
+	if quiet {
+		return quietProgress()
+	}
+	return newProgress(os.Stderr)
+}
+
+// SetProgress passa a reportar o andamento de buscas e enriquecimentos
+// em p; nil desliga.
+func (c *Client) SetProgress(p *progress) {
+	c.progress = p
+}
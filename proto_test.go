@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// protoFields lê os nomes dos campos de cada message de proto/ghsearch.proto.
+func protoFields(t *testing.T) map[string][]string {
+	t.Helper()
+	data, err := os.ReadFile("proto/ghsearch.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := map[string][]string{}
+	field := regexp.MustCompile(`^\s*(?:repeated\s+)?[\w.]+\s+(\w+)\s*=\s*\d+;`)
+	var current string
+	for _, line := range strings.Split(string(data), "\n") {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(line), "message "); ok {
+			current = strings.TrimSuffix(strings.TrimSpace(name), "{")
+			current = strings.TrimSpace(current)
+			continue
+		}
+		if strings.TrimSpace(line) == "}" {
+			current = ""
+			continue
+		}
+		if m := field.FindStringSubmatch(line); m != nil && current != "" {
+			messages[current] = append(messages[current], m[1])
+		}
+	}
+	return messages
+}
+
+// jsonFields devolve as tags JSON dos campos de v. Sem all, só os que a
+// API preenche: os com omitempty são dos enriquecimentos e dos
+// subcomandos, não da busca. renames troca a tag pelo nome que o campo
+// tem no proto.
+func jsonFields(v any, all bool, renames map[string]string) []string {
+	var names []string
+	rt := reflect.TypeOf(v)
+	for i := range rt.NumField() {
+		name, opts, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || !all && strings.Contains(opts, "omitempty") {
+			continue
+		}
+		if r, ok := renames[name]; ok {
+			name = r
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// O proto acompanha os tipos Go: um campo novo na busca (ou no snapshot)
+// tem de aparecer nos dois lugares.
+func TestProtoMatchesGoTypes(t *testing.T) {
+	messages := protoFields(t)
+	for _, tt := range []struct {
+		message string
+		goType  any
+		all     bool
+		renames map[string]string
+	}{
+		{"Repository", Repository{}, false, map[string]string{"license": "license_spdx_id"}},
+		{"Owner", Owner{}, false, nil},
+		{"Issue", Issue{}, false, map[string]string{"repository_url": "repository"}},
+		{"Snapshot", snapshot{}, true, nil},
+	} {
+		want := jsonFields(tt.goType, tt.all, tt.renames)
+		got := messages[tt.message]
+		slices.Sort(want)
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("message %s tem %q, o tipo Go tem %q", tt.message, got, want)
+		}
+	}
+}
+
+// Os exemplos de WatchRequest.schedule são aceitos pelo parser dos jobs do
+// daemon, que é quem interpreta o campo.
+func TestProtoScheduleExamples(t *testing.T) {
+	data, err := os.ReadFile("proto/ghsearch.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, watch, _ := strings.Cut(string(data), "message WatchRequest {")
+	watch, _, _ = strings.Cut(watch, "}")
+	examples := regexp.MustCompile(`"([^"]+)"`).FindAllStringSubmatch(watch, -1)
+	if len(examples) == 0 {
+		t.Fatal("WatchRequest.schedule sem exemplos")
+	}
+	for _, m := range examples {
+		if _, err := parseCron(m[1]); err != nil {
+			t.Errorf("exemplo de schedule %q: %v", m[1], err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// qualifierName devolve o nome (em minúsculas) de um termo no formato
+// nome:valor, ou "" se o termo for texto livre.
+func qualifierName(tok string) string {
+	name, _, ok := strings.Cut(tok, ":")
+	if !ok || name == "" || strings.HasPrefix(name, "\"") {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(name, "-"))
+}
+
+// withQualifiers acrescenta à query os qualificadores que ela ainda não
+// tem. Um qualificador de mesmo nome já presente na query prevalece, para
+// que o usuário possa sobrescrever os padrões (ex: -active com pushed:>2024-01-01).
+func withQualifiers(query string, quals ...string) string {
+	present := map[string]bool{}
+	for _, tok := range queryTerms(query) {
+		if name := qualifierName(tok); name != "" {
+			present[name] = true
+		}
+	}
+	terms := []string{strings.TrimSpace(query)}
+	for _, q := range quals {
+		if !present[qualifierName(q)] {
+			terms = append(terms, q)
+		}
+	}
+	return strings.TrimSpace(strings.Join(terms, " "))
+}
+
+// activeQualifiers são os qualificadores de "projeto mantido": push dentro
+// da janela e repositório não arquivado.
+func activeQualifiers(within time.Duration, now time.Time) []string {
+	return []string{
+		"pushed:>" + now.Add(-within).UTC().Format("2006-01-02"),
+		"archived:false",
+	}
+}
+
+// Limites da sintaxe de busca do GitHub: o texto livre (sem contar
+// qualificadores e operadores) tem no máximo 256 caracteres e a query,
+// no máximo 5 operadores AND, OR ou NOT.
+const (
+	maxQueryText      = 256
+	maxQueryOperators = 5
+)
+
+// repoQualifiers são os qualificadores aceitos por /search/repositories.
+var repoQualifiers = map[string]bool{
+	"in": true, "user": true, "org": true, "repo": true, "size": true,
+	"followers": true, "forks": true, "fork": true, "stars": true,
+	"created": true, "pushed": true, "language": true, "topic": true,
+	"topics": true, "license": true, "is": true, "mirror": true,
+	"template": true, "archived": true, "good-first-issues": true,
+	"help-wanted-issues": true, "has": true, "sort": true,
+}
+
+// splitQuery separa a query em termos, mantendo juntas as partes entre
+// aspas (ex: label:"good first issue"). Aspas sem par são erro.
+func splitQuery(q string) ([]string, error) {
+	var (
+		terms   []string
+		cur     strings.Builder
+		inQuote bool
+	)
+	for _, r := range q {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			cur.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuote:
+			if cur.Len() > 0 {
+				terms = append(terms, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, errors.New(tr("aspas sem par na query"))
+	}
+	if cur.Len() > 0 {
+		terms = append(terms, cur.String())
+	}
+	return terms, nil
+}
+
+// queryTerms separa a query como splitQuery, mas sem falhar: com aspas sem
+// par, separa só pelos espaços. Serve a quem só reescreve a query, e deixa
+// o erro para validateQuery.
+func queryTerms(q string) []string {
+	terms, err := splitQuery(q)
+	if err != nil {
+		return strings.Fields(q)
+	}
+	return terms
+}
+
+// validateQuery confere a query de busca de repositórios antes de gastar
+// uma chamada: aspas balanceadas, qualificadores conhecidos e os limites
+// de tamanho e de operadores do GitHub.
+func validateQuery(q string) error {
+	terms, err := splitQuery(q)
+	if err != nil {
+		return invalid(fmt.Errorf(tr("query inválida %q: %w"), q, err))
+	}
+	if len(terms) == 0 {
+		return invalid(errors.New(tr("query vazia")))
+	}
+
+	var text []string
+	operators := 0
+	for _, t := range terms {
+		switch {
+		case t == "AND" || t == "OR" || t == "NOT":
+			operators++
+		case qualifierName(t) != "":
+			name := qualifierName(t)
+			if !repoQualifiers[name] {
+				return invalid(fmt.Errorf(tr("query inválida %q: qualificador desconhecido %q (para buscar o texto literal, use aspas: \"%s\")"), q, name, t))
+			}
+			if _, value, _ := strings.Cut(t, ":"); value == "" {
+				return invalid(fmt.Errorf(tr("query inválida %q: qualificador %q sem valor"), q, name))
+			}
+		default:
+			text = append(text, t)
+		}
+	}
+	if operators > maxQueryOperators {
+		return invalid(fmt.Errorf(tr("query inválida %q: %d operadores AND/OR/NOT (o GitHub aceita até %d)"), q, operators, maxQueryOperators))
+	}
+	if n := utf8.RuneCountInString(strings.Join(text, " ")); n > maxQueryText {
+		return invalid(fmt.Errorf(tr("query inválida: o texto tem %d caracteres (o GitHub aceita até %d, sem contar qualificadores)"), n, maxQueryText))
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// querySeeds são queries válidas e quebradas que servem de ponto de
+// partida para os fuzzers de query.
+var querySeeds = []string{
+	"language:go",
+	"Language:Go  stars:>10",
+	`label:"good first issue" language:rust`,
+	`"machine learning" in:description NOT deprecated`,
+	`"a b:1" c`,
+	`topic:cli -topic:hacktoberfest stars:10..500 pushed:>2024-01-01`,
+	`foo" bar`,
+	`"`,
+	`stars:`,
+	`:valor`,
+	`-:x`,
+	`unknown:qualifier`,
+	"a AND b OR c NOT d AND e OR f NOT g",
+	strings.Repeat("x", maxQueryText+1),
+	"\t\n   language:go ",
+	"\xff\xfe",
+}
+
+func FuzzValidateQuery(f *testing.F) {
+	for _, q := range querySeeds {
+		f.Add(q)
+	}
+	f.Fuzz(func(t *testing.T, q string) {
+		err := validateQuery(q)
+		if err == nil {
+			// Uma query aceita tem aspas balanceadas e algum termo.
+			terms, splitErr := splitQuery(q)
+			if splitErr != nil || len(terms) == 0 {
+				t.Fatalf("validateQuery(%q) aceitou, mas splitQuery = %q, %v", q, terms, splitErr)
+			}
+			return
+		}
+		if kind, _ := classifyError(err); kind != kindUsage {
+			t.Fatalf("validateQuery(%q) = %v, classe %q, quer %q", q, err, kind, kindUsage)
+		}
+	})
+}
+
+func FuzzSplitQuery(f *testing.F) {
+	for _, q := range querySeeds {
+		f.Add(q)
+	}
+	f.Fuzz(func(t *testing.T, q string) {
+		terms, err := splitQuery(q)
+		if err != nil {
+			return
+		}
+		// Os termos são a query sem os espaços de fora das aspas: juntá-los
+		// de novo e separar dá os mesmos termos.
+		again, err := splitQuery(strings.Join(terms, " "))
+		if err != nil || strings.Join(again, "\x00") != strings.Join(terms, "\x00") {
+			t.Fatalf("splitQuery(%q) = %q, mas de novo = %q, %v", q, terms, again, err)
+		}
+		for _, term := range terms {
+			if term == "" {
+				t.Fatalf("splitQuery(%q) devolveu termo vazio: %q", q, terms)
+			}
+		}
+	})
+}
+
+func FuzzNormalizeQuery(f *testing.F) {
+	for _, q := range querySeeds {
+		f.Add(q)
+	}
+	f.Fuzz(func(t *testing.T, q string) {
+		n := normalizeQuery(q)
+		if again := normalizeQuery(n); again != n {
+			t.Fatalf("normalizeQuery não é idempotente: %q → %q → %q", q, n, again)
+		}
+		// Normalizar não muda o que a validação decide.
+		if utf8.ValidString(q) && (validateQuery(q) == nil) != (validateQuery(n) == nil) {
+			t.Fatalf("validateQuery(%q) = %v, mas validateQuery(%q) = %v", q, validateQuery(q), n, validateQuery(n))
+		}
+	})
+}
+
+func FuzzWithQualifiers(f *testing.F) {
+	for _, q := range querySeeds {
+		f.Add(q)
+	}
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	quals := append(activeQualifiers(90*24*time.Hour, now), "size:<=1024")
+	f.Fuzz(func(t *testing.T, q string) {
+		got := withQualifiers(q, quals...)
+		if again := withQualifiers(got, quals...); again != got {
+			t.Fatalf("withQualifiers não é idempotente: %q → %q → %q", q, got, again)
+		}
+		if !strings.HasPrefix(got, strings.TrimSpace(q)) {
+			t.Fatalf("withQualifiers(%q) = %q perdeu a query original", q, got)
+		}
+	})
+}
+
+func FuzzSearchParams(f *testing.F) {
+	for _, q := range querySeeds {
+		f.Add(q, "stars", "desc", 30, 2)
+	}
+	f.Add("q&sort=forks", "a=b", "&", -1, 0)
+	f.Fuzz(func(t *testing.T, q, sortBy, order string, perPage, page int) {
+		opts := SearchOptions{Query: q, Sort: sortBy, Order: order, PerPage: perPage, Page: page}
+		values, err := url.ParseQuery(opts.params().Encode())
+		if err != nil {
+			t.Fatalf("params de %+v não voltam da URL: %v", opts, err)
+		}
+		// Nada na query vaza para outros parâmetros.
+		if values.Get("q") != q {
+			t.Fatalf("q = %q, quer %q", values.Get("q"), q)
+		}
+		if sortBy != "" && values.Get("sort") != sortBy || order != "" && values.Get("order") != order {
+			t.Fatalf("sort/order = %q/%q, quer %q/%q", values.Get("sort"), values.Get("order"), sortBy, order)
+		}
+		if len(values["q"]) != 1 {
+			t.Fatalf("%d parâmetros q na URL de %+v", len(values["q"]), opts)
+		}
+	})
+}
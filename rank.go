@@ -0,0 +1,179 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ranker reordena os resultados no cliente, depois da busca. Assim a
+// ordem exibida não fica presa às poucas ordenações da API (stars, forks,
+// help-wanted-issues, updated).
+type Ranker interface {
+	Rank(repos []Repository, now time.Time)
+}
+
+// scoreRanker ordena pela pontuação de cada repositório, da maior para a
+// menor, mantendo a ordem da API nos empates.
+type scoreRanker func(r Repository, now time.Time) float64
+
+func (score scoreRanker) Rank(repos []Repository, now time.Time) {
+	sort.SliceStable(repos, func(a, b int) bool {
+		return score(repos[a], now) > score(repos[b], now)
+	})
+}
+
+// starVelocity é a média de estrelas por dia desde a criação; projetos
+// novos e em alta passam à frente de projetos antigos e estáveis.
+func starVelocity(r Repository, now time.Time) float64 {
+	if r.CreatedAt.IsZero() {
+		return 0
+	}
+	days := max(now.Sub(r.CreatedAt).Hours()/24, 1)
+	return math.Round(float64(r.Stars)/days*1000) / 1000
+}
+
+// recency vai de 1 (push agora) a 0, caindo pela metade a cada
+// healthHalfLife sem push.
+func recency(r Repository, now time.Time) float64 {
+	if r.PushedAt.IsZero() {
+		return 0
+	}
+	age := max(now.Sub(r.PushedAt), 0)
+	return math.Pow(0.5, float64(age)/float64(healthHalfLife))
+}
+
+// logCount é log10(n+1), a escala das contagens nos critérios. Uma
+// contagem negativa, que só uma resposta quebrada traria, vale como 0 em
+// vez de virar NaN e bagunçar a ordenação e a saída JSON.
+func logCount(n int) float64 {
+	return math.Log10(float64(max(n, 0)) + 1)
+}
+
+// rankComponents são os critérios aceitos em -rank-weights. Contagens
+// entram em log10, para que um projeto gigante não anule os demais
+// critérios.
+var rankComponents = map[string]func(r Repository, now time.Time) float64{
+	"stars":    func(r Repository, _ time.Time) float64 { return logCount(r.Stars) },
+	"forks":    func(r Repository, _ time.Time) float64 { return logCount(r.Forks) },
+	"watchers": func(r Repository, _ time.Time) float64 { return logCount(r.Watchers) },
+	"issues":   func(r Repository, _ time.Time) float64 { return logCount(r.OpenIssues) },
+	"velocity": starVelocity,
+	"recency":  recency,
+	"health":   healthScore,
+}
+
+// rankers são as estratégias prontas de -rank.
+var rankers = map[string]Ranker{
+	"stars":    scoreRanker(func(r Repository, _ time.Time) float64 { return float64(r.Stars) }),
+	"forks":    scoreRanker(func(r Repository, _ time.Time) float64 { return float64(r.Forks) }),
+	"velocity": scoreRanker(starVelocity),
+	"health":   scoreRanker(healthScore),
+}
+
+// weightedRanker combina critérios com pesos. Cada critério é normalizado
+// para 0..1 pelo maior valor do próprio resultado antes de ponderar, de
+// modo que os pesos significam importância relativa.
+type weightedRanker map[string]float64
+
+func (w weightedRanker) Rank(repos []Repository, now time.Time) {
+	// Ordem fixa dos critérios: somas de float em ordem diferente podem
+	// desempatar de forma diferente a cada execução.
+	names := make([]string, 0, len(w))
+	for name := range w {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	scores := make([]float64, len(repos))
+	for _, name := range names {
+		weight, component := w[name], rankComponents[name]
+		values := make([]float64, len(repos))
+		top := 0.0
+		for i, r := range repos {
+			values[i] = component(r, now)
+			top = max(top, math.Abs(values[i]))
+		}
+		if top == 0 {
+			continue
+		}
+		for i := range repos {
+			scores[i] += weight * values[i] / top
+		}
+	}
+
+	order := make([]int, len(repos))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+	sorted := make([]Repository, len(repos))
+	for i, j := range order {
+		sorted[i] = repos[j]
+	}
+	copy(repos, sorted)
+}
+
+// parseRankWeights converte "stars=0.6,recency=0.4" em um weightedRanker.
+// Pesos negativos penalizam o critério.
+func parseRankWeights(spec string) (weightedRanker, error) {
+	w := weightedRanker{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if _, known := rankComponents[name]; !known {
+			return nil, invalid(fmt.Errorf(tr("critério desconhecido em -rank-weights: %q (disponíveis: %s)"), name, mapKeys(rankComponents)))
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !ok || err != nil {
+			return nil, invalid(fmt.Errorf(tr("peso inválido em -rank-weights: %q (use critério=peso, ex: stars=0.6)"), part))
+		}
+		w[name] = weight
+	}
+	if len(w) == 0 {
+		return nil, invalid(errors.New(tr("-rank-weights vazio")))
+	}
+	return w, nil
+}
+
+// parseRanker escolhe o Ranker de -rank e -rank-weights; sem nenhum dos
+// dois, devolve nil (fica a ordem da API).
+func parseRanker(name, weights string) (Ranker, error) {
+	if weights != "" {
+		if name != "" && name != "weighted" {
+			return nil, invalid(fmt.Errorf(tr("-rank-weights só combina com -rank weighted, recebido %q"), name))
+		}
+		return parseRankWeights(weights)
+	}
+	if name == "" {
+		return nil, nil
+	}
+	if name == "weighted" {
+		return nil, invalid(errors.New(tr("-rank weighted exige -rank-weights")))
+	}
+	r, ok := rankers[name]
+	if !ok {
+		return nil, invalid(fmt.Errorf(tr("-rank desconhecido: %q (disponíveis: %s, weighted)"), name, mapKeys(rankers)))
+	}
+	return r, nil
+}
+
+// mapKeys lista as chaves de m em ordem alfabética, separadas por vírgula.
+func mapKeys[V any](m map[string]V) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateBucket descreve a quota de um grupo de endpoints (core, search...).
+type RateBucket struct {
+	Limit     int   `json:"limit"`
+	Remaining int   `json:"remaining"`
+	Used      int   `json:"used"`
+	Reset     int64 `json:"reset"` // Unix timestamp em que a quota é renovada
+}
+
+// ResetTime converte o timestamp de renovação em time.Time.
+func (b RateBucket) ResetTime() time.Time {
+	return time.Unix(b.Reset, 0)
+}
+
+// parseRate lê a quota dos headers X-RateLimit-* que acompanham cada
+// resposta da API. Headers ausentes resultam em campos zerados.
+func parseRate(h http.Header) RateBucket {
+	atoi := func(name string) int64 {
+		n, _ := strconv.ParseInt(h.Get(name), 10, 64)
+		return n
+	}
+	return RateBucket{
+		Limit:     int(atoi("X-RateLimit-Limit")),
+		Remaining: int(atoi("X-RateLimit-Remaining")),
+		Used:      int(atoi("X-RateLimit-Used")),
+		Reset:     atoi("X-RateLimit-Reset"),
+	}
+}
+
+// RateLimits mapeia a resposta de /rate_limit.
+type RateLimits struct {
+	Resources struct {
+		Core   RateBucket `json:"core"`
+		Search RateBucket `json:"search"`
+	} `json:"resources"`
+}
+
+// User mapeia os campos de /user que nos interessam.
+type User struct {
+	Login string `json:"login"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+}
+
+// RateLimit consulta a quota atual. Esse endpoint não consome quota.
+func (c *Client) RateLimit(ctx context.Context) (*RateLimits, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/rate_limit", nil)
+	if err != nil {
+		return nil, err
+	}
+	var limits RateLimits
+	if _, err := c.do(req, &limits); err != nil {
+		return nil, err
+	}
+	return &limits, nil
+}
+
+// CurrentUser devolve o usuário dono do token e os escopos concedidos,
+// lidos do header X-OAuth-Scopes (vazio para tokens fine-grained).
+func (c *Client) CurrentUser(ctx context.Context) (*User, []string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/user", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var user User
+	resp, err := c.do(req, &user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var scopes []string
+	for _, s := range strings.Split(resp.Header.Get("X-OAuth-Scopes"), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return &user, scopes, nil
+}
+
+// rateLimiter espaça as requisições para respeitar um limite por minuto e,
+// quando a API informa que a quota acabou, segura tudo até a renovação.
+// É seguro para uso concorrente.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // espaço mínimo entre requisições
+	next     time.Time     // quando a próxima requisição pode sair
+}
+
+// newRateLimiter cria um limitador de perMinute requisições por minuto.
+// perMinute <= 0 não espaça as requisições, mas ainda respeita a quota.
+func newRateLimiter(perMinute int) *rateLimiter {
+	l := &rateLimiter{}
+	if perMinute > 0 {
+		l.interval = time.Minute / time.Duration(perMinute)
+	}
+	return l
+}
+
+// Wait bloqueia até a próxima requisição poder sair ou ctx ser cancelado.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ready devolve quando a próxima requisição poderá sair.
+func (l *rateLimiter) ready() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.next
+}
+
+// Observe ajusta o limitador a partir dos headers de quota de uma
+// resposta: com a quota zerada, nenhuma requisição sai antes do reset.
+func (l *rateLimiter) Observe(rate RateBucket) {
+	if rate.Limit == 0 || rate.Remaining > 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if reset := rate.ResetTime(); reset.After(l.next) {
+		l.next = reset
+	}
+}
+
+// searchRatePerMinute é o limite da API de busca: 30 requisições por
+// minuto com token e 10 sem.
+func searchRatePerMinute(authenticated bool) int {
+	if authenticated {
+		return 30
+	}
+	return 10
+}
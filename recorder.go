@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// cassette é o formato em disco de uma resposta gravada.
+// O corpo fica como texto para que as fixtures possam ser lidas e editadas.
+type cassette struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// requestKey identifica uma requisição pelo método e URL completa.
+// Headers (inclusive Authorization) ficam de fora para que uma gravação
+// feita com token possa ser reproduzida sem ele.
+func requestKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// recordingTransport executa as requisições normalmente e salva cada
+// resposta em dir/<hash>.json.
+type recordingTransport struct {
+	dir  string
+	next http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf(tr("falha ao ler corpo da resposta: %w"), err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c := cassette{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       string(body),
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf(tr("falha ao codificar gravação: %w"), err)
+	}
+	path := filepath.Join(t.dir, requestKey(req)+".json")
+	if err := writeFileAtomic(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf(tr("falha ao salvar gravação: %w"), err)
+	}
+	return resp, nil
+}
+
+// replayTransport responde a partir das gravações, sem acessar a rede.
+// Uma requisição sem gravação correspondente é um erro.
+type replayTransport struct {
+	dir string
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := filepath.Join(t.dir, requestKey(req)+".json")
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf(tr("nenhuma gravação para %s %s"), req.Method, req.URL)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(tr("falha ao ler gravação: %w"), err)
+	}
+
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf(tr("gravação corrompida em %s: %w"), path, err)
+	}
+	return c.response(req), nil
+}
+
+// newTransport escolhe o transporte conforme as flags -record/-replay,
+// usando next para as requisições reais. As duas flags ao mesmo tempo
+// não fazem sentido e resultam em erro.
+func newTransport(recordDir, replayDir string, next http.RoundTripper) (http.RoundTripper, error) {
+	switch {
+	case recordDir != "" && replayDir != "":
+		return nil, errors.New(tr("use -record ou -replay, não os dois"))
+	case recordDir != "":
+		if err := os.MkdirAll(recordDir, 0o755); err != nil {
+			return nil, fmt.Errorf(tr("falha ao criar diretório de gravação: %w"), err)
+		}
+		return &recordingTransport{dir: recordDir, next: next}, nil
+	case replayDir != "":
+		return &replayTransport{dir: replayDir}, nil
+	}
+	return next, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// repoPath monta o caminho /repos/{owner}/{repo} a partir do full_name.
+func repoPath(fullName string, suffix string) string {
+	return "/repos/" + fullName + suffix
+}
+
+// GetReadme devolve o README do repositório em texto puro. Repositórios
+// sem README devolvem string vazia, sem erro.
+func (c *Client) GetReadme(ctx context.Context, fullName string) (string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, repoPath(fullName, "/readme"), nil)
+	if err != nil {
+		return "", err
+	}
+	// Com esse media type a API devolve o arquivo bruto em vez do JSON em base64.
+	req.Header.Set("Accept", "application/vnd.github.raw")
+
+	_, body, err := c.send(req)
+	if isStatus(err, http.StatusNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// GetFile devolve o conteúdo bruto de um arquivo do branch padrão.
+// Arquivos inexistentes devolvem string vazia, sem erro.
+func (c *Client) GetFile(ctx context.Context, fullName, path string) (string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, repoPath(fullName, "/contents/"+path), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github.raw")
+
+	_, body, err := c.send(req)
+	if isStatus(err, http.StatusNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// GetTopics devolve os tópicos cadastrados no repositório.
+func (c *Client) GetTopics(ctx context.Context, fullName string) ([]string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, repoPath(fullName, "/topics"), nil)
+	if err != nil {
+		return nil, err
+	}
+	var payload struct {
+		Names []string `json:"names"`
+	}
+	if _, err := c.do(req, &payload); err != nil {
+		return nil, err
+	}
+	return payload.Names, nil
+}
+
+// Release mapeia uma release publicada.
+type Release struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	URL         string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// GetLatestRelease devolve a release mais recente (não draft nem
+// pre-release). Repositórios sem releases devolvem nil, sem erro.
+func (c *Client) GetLatestRelease(ctx context.Context, fullName string) (*Release, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, repoPath(fullName, "/releases/latest"), nil)
+	if err != nil {
+		return nil, err
+	}
+	var release Release
+	_, err = c.do(req, &release)
+	if isStatus(err, http.StatusNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// GetRepository busca os metadados completos de um repositório.
+func (c *Client) GetRepository(ctx context.Context, fullName string) (*Repository, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, repoPath(fullName, ""), nil)
+	if err != nil {
+		return nil, err
+	}
+	var repo Repository
+	if _, err := c.do(req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
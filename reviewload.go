@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// reviewLoad é o backlog de revisão de um repositório: os pull requests
+// abertos e há quanto tempo esperam. Com mais PRs que a amostra, as idades
+// valem só para os mais antigos (Sampled).
+type reviewLoad struct {
+	Repo          string  `json:"repo"`
+	Stars         int     `json:"stars"`
+	OpenPRs       int     `json:"open_prs"`
+	AvgAgeDays    float64 `json:"avg_age_days"`
+	OldestNumber  int     `json:"oldest_number,omitempty"`
+	OldestAgeDays int     `json:"oldest_age_days"`
+	Sampled       bool    `json:"sampled"`
+}
+
+// reviewLoadQuery é a busca dos PRs abertos de um repositório; drafts só
+// entram com includeDrafts, pois ainda não pedem revisão.
+func reviewLoadQuery(fullName string, includeDrafts bool) string {
+	q := fmt.Sprintf("repo:%s is:pr state:open", fullName)
+	if !includeDrafts {
+		q += " draft:false"
+	}
+	return q
+}
+
+// measureReviewLoad calcula o backlog a partir da busca de PRs, que vem
+// do mais antigo para o mais novo.
+func measureReviewLoad(repo Repository, result *IssueSearchResult, now time.Time) reviewLoad {
+	load := reviewLoad{
+		Repo:    repo.FullName,
+		Stars:   repo.Stars,
+		OpenPRs: result.TotalCount,
+		Sampled: result.TotalCount > len(result.Items),
+	}
+	if len(result.Items) == 0 {
+		return load
+	}
+	total := 0.0
+	for _, pr := range result.Items {
+		total += now.Sub(pr.CreatedAt).Hours() / 24
+	}
+	load.AvgAgeDays = total / float64(len(result.Items))
+	oldest := result.Items[0]
+	load.OldestNumber = oldest.Number
+	load.OldestAgeDays = int(now.Sub(oldest.CreatedAt).Hours() / 24)
+	return load
+}
+
+// sortReviewLoad ordena do maior backlog para o menor: por PRs abertos
+// ou, com by "age", pela idade média.
+func sortReviewLoad(loads []reviewLoad, by string) {
+	sort.SliceStable(loads, func(a, b int) bool {
+		if by == "age" && loads[a].AvgAgeDays != loads[b].AvgAgeDays {
+			return loads[a].AvgAgeDays > loads[b].AvgAgeDays
+		}
+		if loads[a].OpenPRs != loads[b].OpenPRs {
+			return loads[a].OpenPRs > loads[b].OpenPRs
+		}
+		return loads[a].AvgAgeDays > loads[b].AvgAgeDays
+	})
+}
+
+func writeReviewLoad(w io.Writer, format string, loads []reviewLoad) error {
+	switch format {
+	case formatTable, formatText:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, tr("REPOSITÓRIO\tESTRELAS\tPRS ABERTOS\tIDADE MÉDIA (DIAS)\tMAIS ANTIGO"))
+		for _, l := range loads {
+			avg := strconv.FormatFloat(l.AvgAgeDays, 'f', 0, 64)
+			if l.Sampled {
+				avg = "≈" + avg
+			}
+			oldest := "-"
+			if l.OldestNumber > 0 {
+				oldest = fmt.Sprintf("#%d (%dd)", l.OldestNumber, l.OldestAgeDays)
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\n", l.Repo, l.Stars, l.OpenPRs, avg, oldest)
+		}
+		return tw.Flush()
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"repo", "stars", "open_prs", "avg_age_days", "oldest_number", "oldest_age_days", "sampled"})
+		for _, l := range loads {
+			cw.Write([]string{l.Repo, strconv.Itoa(l.Stars), strconv.Itoa(l.OpenPRs), strconv.FormatFloat(l.AvgAgeDays, 'f', 1, 64),
+				strconv.Itoa(l.OldestNumber), strconv.Itoa(l.OldestAgeDays), strconv.FormatBool(l.Sampled)})
+		}
+		cw.Flush()
+		return cw.Error()
+	case formatJSON:
+		return encodeJSON(w, loads)
+	}
+	return fmt.Errorf(tr("formato desconhecido: %q (use text, table, csv ou json)"), format)
+}
+
+// runReviewLoad implementa `review-load -q "..."`: para cada repositório
+// da busca, conta os PRs abertos e a idade média deles, ordenando pelo
+// backlog de revisão. Ajuda a avaliar se os mantenedores respondem antes
+// de adotar uma dependência.
+func runReviewLoad(args []string) error {
+	fs := newFlagSet("review-load")
+	sf := addSearchFlags(fs, 20)
+	sample := fs.Int("sample", maxPerPage, "máximo de PRs analisados por repositório (os mais antigos primeiro)")
+	sortBy := fs.String("by", "open", "ordena por open (PRs abertos) ou age (idade média)")
+	includeDrafts := fs.Bool("include-drafts", false, "conta também os PRs em rascunho")
+	format := fs.String("format", formatTable, "formato de saída: table, csv ou json")
+	quiet := fs.Bool("quiet", false, "não mostra o progresso no stderr")
+	cf := addClientFlags(fs)
+	fs.Parse(args)
+
+	if err := sf.validate(); err != nil {
+		return err
+	}
+	if err := validateQuery(sf.query); err != nil {
+		return err
+	}
+	if *sample < 1 || *sample > maxSearchResults {
+		return invalid(fmt.Errorf(tr("-sample deve estar entre 1 e %d"), maxSearchResults))
+	}
+	if *sortBy != "open" && *sortBy != "age" {
+		return invalid(fmt.Errorf(tr("-by aceita open ou age, recebido %q"), *sortBy))
+	}
+	switch *format {
+	case formatText, formatTable, formatCSV, formatJSON:
+	default:
+		return invalid(fmt.Errorf(tr("formato desconhecido: %q (use text, table, csv ou json)"), *format))
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	client.SetProgress(newProgressFlag(*quiet))
+	ctx, cancel := cf.context()
+	defer cancel()
+
+	result, err := client.SearchAllRepositories(ctx, sf.options(), sf.limit)
+	if err != nil {
+		return err
+	}
+
+	// Cada repositório custa ao menos uma chamada à busca de issues, que
+	// divide com a busca de repositórios o limite por minuto. Um
+	// repositório cuja busca falhar fica de fora e é listado no fim; só o
+	// cancelamento interrompe.
+	now := time.Now()
+	loads := make([]reviewLoad, 0, len(result.Items))
+	var skipped []Repository
+	client.progress.Start(tr("carga de revisão"), len(result.Items), tr("repositórios"))
+	repoCtx := withoutProgress(ctx)
+	for _, repo := range result.Items {
+		opts := SearchOptions{
+			Query:   reviewLoadQuery(repo.FullName, *includeDrafts),
+			Sort:    "created",
+			Order:   "asc",
+			PerPage: min(*sample, maxPerPage),
+		}
+		prs, err := client.SearchAllIssues(repoCtx, opts, *sample)
+		client.progress.Step(0)
+		if ctx.Err() != nil {
+			client.progress.Finish()
+			return ctx.Err()
+		}
+		if err != nil {
+			repo.markUnavailable("prs", err)
+			skipped = append(skipped, repo)
+			continue
+		}
+		loads = append(loads, measureReviewLoad(repo, prs, now))
+	}
+	client.progress.Finish()
+	sortReviewLoad(loads, *sortBy)
+	if err := writeReviewLoad(os.Stdout, *format, loads); err != nil {
+		return err
+	}
+	writeSkipped(os.Stderr, skipped)
+	return nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// s3Sink grava cada snapshot como um objeto JSON em um bucket S3 ou
+// compatível (MinIO, R2...). As credenciais seguem as variáveis padrão da
+// AWS: AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN,
+// AWS_REGION e, para serviços compatíveis, AWS_ENDPOINT_URL. Os objetos
+// usam endereçamento por caminho ({endpoint}/{bucket}/{chave}), aceito por
+// todos os compatíveis.
+type s3Sink struct {
+	bucket    string
+	prefix    string
+	endpoint  string
+	region    string
+	accessKey string
+	secretKey string
+	session   string
+	client    Doer
+}
+
+// newS3Sink interpreta "s3://bucket/prefixo" e lê as credenciais do ambiente.
+func newS3Sink(spec string) (*s3Sink, error) {
+	u, err := url.Parse(spec)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf(tr("sink S3 inválido %q (use s3://<bucket>/<prefixo>)"), spec)
+	}
+	s := &s3Sink{
+		bucket:    u.Host,
+		prefix:    strings.Trim(u.Path, "/"),
+		endpoint:  os.Getenv("AWS_ENDPOINT_URL"),
+		region:    os.Getenv("AWS_REGION"),
+		accessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		session:   os.Getenv("AWS_SESSION_TOKEN"),
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+	if s.region == "" {
+		s.region = "us-east-1"
+	}
+	if s.endpoint == "" {
+		s.endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", s.region)
+	}
+	s.endpoint = strings.TrimRight(s.endpoint, "/")
+	if s.accessKey == "" || s.secretKey == "" {
+		return nil, errors.New(tr("sink S3 requer AWS_ACCESS_KEY_ID e AWS_SECRET_ACCESS_KEY"))
+	}
+	return s, nil
+}
+
+func (s *s3Sink) String() string { return "s3://" + s.bucket + "/" + s.prefix }
+
+// Write grava o snapshot em <prefixo>/<job ou query>/<timestamp>.json.
+func (s *s3Sink) Write(ctx context.Context, snap snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf(tr("falha ao codificar snapshot: %w"), err)
+	}
+	name := snap.Job
+	if name == "" {
+		name = querySlug(snap.Query)
+	}
+	key := name + "/" + snap.TakenAt.UTC().Format("20060102T150405Z") + ".json"
+	if s.prefix != "" {
+		key = s.prefix + "/" + key
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.endpoint+"/"+s.bucket+"/"+key, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf(tr("falha ao criar requisição: %w"), err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.sign(req, data, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf(tr("falha ao executar requisição: %w"), err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf(tr("S3 retornou status não-OK: %s"), resp.Status)
+	}
+	return nil
+}
+
+// sign assina a requisição com AWS Signature Version 4.
+func (s *s3Sink) sign(req *http.Request, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.session != "" {
+		req.Header.Set("X-Amz-Security-Token", s.session)
+	}
+
+	// Headers assinados, em minúsculas e ordem alfabética.
+	signed := []string{"content-type", "host", "x-amz-content-sha256", "x-amz-date"}
+	if s.session != "" {
+		signed = append(signed, "x-amz-security-token")
+	}
+	var canonicalHeaders strings.Builder
+	for _, h := range signed {
+		value := req.Header.Get(h)
+		if h == "host" {
+			value = req.URL.Host
+		}
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", h, strings.TrimSpace(value))
+	}
+	signedHeaders := strings.Join(signed, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), day)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
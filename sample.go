@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"math/rand/v2"
+	"sort"
+)
+
+// newSampleRand cria o gerador do sorteio de -sample; seed 0 escolhe uma
+// semente aleatória.
+func newSampleRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return rand.New(rand.NewPCG(uint64(seed), uint64(seed)))
+}
+
+// SampleRepositories sorteia n repositórios entre todos os resultados da
+// busca, sem buscar todas as páginas: a primeira diz quantos resultados
+// são, então n posições são sorteadas e só as páginas que as contêm são
+// pedidas. Os escolhidos ficam na ordem da busca. Como a API só expõe os
+// 1000 primeiros resultados, a amostra é desses 1000 na ordenação pedida.
+//
+// Cada posição sorteada custa no máximo uma página, então um -per-page
+// maior junta mais posições por chamada. Uma página que falha depois de
+// outras já recebidas deixa a amostra menor, com um *PartialError, como
+// em SearchAllRepositories.
+func (c *Client) SampleRepositories(ctx context.Context, opts SearchOptions, n int, rng *rand.Rand) (*SearchResult, error) {
+	opts.Page = 1
+	it := c.RepositoryIterator(opts)
+	perPage := it.opts.PerPage
+
+	prog := c.progressFor(ctx)
+	prog.Start(tr("amostra"), 0, tr("páginas"))
+	defer prog.Finish()
+
+	first, err := it.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prog.Step(len(first.Items))
+
+	size := min(first.TotalCount, maxSearchResults)
+	if first.LastPage > 0 {
+		size = min(size, first.LastPage*perPage)
+	}
+	offsets := samplePositions(size, n, rng)
+
+	// As posições sorteadas, agrupadas por página.
+	byPage := map[int][]int{}
+	var pages []int
+	for _, off := range offsets {
+		page := off/perPage + 1
+		if byPage[page] == nil {
+			pages = append(pages, page)
+		}
+		byPage[page] = append(byPage[page], off%perPage)
+	}
+	prog.SetTotal(len(pages) + 1)
+	if byPage[1] != nil {
+		prog.SetTotal(len(pages))
+	}
+
+	result := &SearchResult{TotalCount: first.TotalCount}
+	fetched := 1
+	for _, page := range pages {
+		items := first.Items
+		if page != 1 {
+			o := opts
+			o.Page = page
+			got, err := c.RepositoryIterator(o).Next(ctx)
+			if err != nil && len(result.Items) == 0 {
+				return nil, err
+			}
+			if err != nil {
+				result.Partial = &PartialError{Pages: fetched, Items: len(result.Items), Err: err}
+				return result, result.Partial
+			}
+			items = got.Items
+			fetched++
+			prog.Step(len(items))
+		}
+		// Os resultados podem mudar entre uma página e outra; uma posição
+		// que deixou de existir fica de fora da amostra.
+		for _, pos := range byPage[page] {
+			if pos < len(items) {
+				result.Items = append(result.Items, items[pos])
+			}
+		}
+	}
+	return result, nil
+}
+
+// samplePositions sorteia min(n, size) posições distintas em [0, size), em
+// ordem crescente.
+func samplePositions(size, n int, rng *rand.Rand) []int {
+	if n >= size {
+		all := make([]int, size)
+		for i := range all {
+			all[i] = i
+		}
+		return all
+	}
+	picked := rng.Perm(size)[:n]
+	sort.Ints(picked)
+	return picked
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Limites impostos pela API de busca do GitHub.
+const (
+	maxPerPage       = 100  // per_page máximo aceito
+	maxSearchResults = 1000 // a busca nunca devolve além do 1000º resultado
+)
+
+// SearchOptions reúne os parâmetros de uma busca.
+type SearchOptions struct {
+	Query   string // O termo de busca (ex: "language:go")
+	Sort    string // A "FEATURE" para ordenar (ex: "stars")
+	Order   string // A direção (ex: "desc")
+	PerPage int    // Resultados por página (1..100); 0 usa o padrão da API
+	Page    int    // Página a buscar, começando em 1; 0 usa a primeira
+}
+
+// params converte as opções nos parâmetros de URL de um endpoint de busca.
+func (opts SearchOptions) params() url.Values {
+	params := url.Values{}
+	params.Add("q", opts.Query)
+	if opts.Sort != "" {
+		params.Add("sort", opts.Sort)
+	}
+	if opts.Order != "" {
+		params.Add("order", opts.Order)
+	}
+	if opts.PerPage > 0 {
+		params.Add("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page > 0 {
+		params.Add("page", strconv.Itoa(opts.Page))
+	}
+	return params
+}
+
+// searchPage busca uma página de qualquer endpoint de busca
+// (/search/repositories, /search/issues...) e devolve também a resposta
+// HTTP, de onde saem os headers de quota.
+func searchPage[T any](ctx context.Context, c *Client, endpoint string, opts SearchOptions) (total int, items []T, resp *http.Response, err error) {
+	ctx, cancel := withTimeout(ctx, c.searchTimeout)
+	defer cancel()
+
+	// 1. Criar a requisição GET com os parâmetros codificados de forma segura
+	req, err := c.newRequest(ctx, http.MethodGet, endpoint, opts.params())
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if endpoint == "/search/repositories" {
+		req.Header.Set("Accept", topicsMediaType)
+	}
+	// Com o indicador de progresso ligado, o log por requisição só poluiria.
+	if c.progress == nil {
+		log.Printf(tr("Consultando a API do GitHub: %s\n"), req.URL)
+	}
+
+	// 2. Executar e decodificar (Unmarshal) o JSON na nossa struct
+	var payload struct {
+		TotalCount int `json:"total_count"`
+		Items      []T `json:"items"`
+	}
+	resp, err = c.do(req, &payload)
+	if err != nil {
+		return 0, nil, resp, err
+	}
+	return payload.TotalCount, payload.Items, resp, nil
+}
+
+/**
+ * SearchRepositories é a função principal que consome a API de busca.
+ * Ela é responsável por construir a query, fazer a chamada e decodificar a resposta.
+ * Cada chamada busca uma única página.
+ */
+func (c *Client) SearchRepositories(ctx context.Context, opts SearchOptions) (*SearchResult, error) {
+	total, items, _, err := searchPage[Repository](ctx, c, "/search/repositories", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchResult{TotalCount: total, Items: items}, nil
+}
+
+// SearchAllRepositories percorre as páginas da busca até reunir limit
+// resultados, acabarem os resultados ou atingir o teto de 1000 do GitHub.
+// Se uma página falhar depois de outras já coletadas, o erro é um
+// *PartialError e o resultado parcial também é devolvido (com Partial
+// preenchido); quem trata qualquer erro como fatal continua tudo-ou-nada.
+func (c *Client) SearchAllRepositories(ctx context.Context, opts SearchOptions, limit int) (*SearchResult, error) {
+	total, items, err := collect(ctx, c.RepositoryIterator(opts), limit)
+	var partial *PartialError
+	if err != nil && !errors.As(err, &partial) {
+		return nil, err
+	}
+	return &SearchResult{TotalCount: total, Items: items, Partial: partial}, err
+}
+
+// PartialError indica que a busca falhou no meio do caminho, depois de já
+// ter coletado Items resultados em Pages páginas.
+type PartialError struct {
+	Pages int
+	Items int
+	Err   error
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf(tr("resultados parciais (%d itens em %d páginas): %v"), e.Items, e.Pages, e.Err)
+}
+
+func (e *PartialError) Unwrap() error { return e.Err }
+
+// ErrNoMorePages é devolvido por SearchIterator.Next quando a busca acabou.
+var ErrNoMorePages error = trError("não há mais páginas")
+
+// Page é uma página de resultados entregue pelo SearchIterator, com os
+// metadados necessários para decidir se vale a pena pedir a próxima.
+type Page[T any] struct {
+	Number     int        // número desta página, começando em 1
+	Items      []T        // resultados desta página
+	TotalCount int        // total de resultados da busca (não só desta página)
+	NextPage   int        // próxima página a ser buscada; 0 quando esta é a última
+	LastPage   int        // total de páginas, segundo o header Link (já limitado a 1000 resultados)
+	Rate       RateBucket // quota de busca restante após esta chamada
+}
+
+// SearchIterator busca as páginas sob demanda: cada Next faz uma única
+// chamada, de forma que o consumidor pode parar quando quiser.
+type SearchIterator[T any] struct {
+	client   *Client
+	endpoint string
+	opts     SearchOptions
+	next     int // próxima página; 0 quando acabou
+}
+
+// RepositoryIterator cria um iterador sobre /search/repositories.
+func (c *Client) RepositoryIterator(opts SearchOptions) *SearchIterator[Repository] {
+	return newSearchIterator[Repository](c, "/search/repositories", opts)
+}
+
+// IssueIterator cria um iterador sobre /search/issues.
+func (c *Client) IssueIterator(opts SearchOptions) *SearchIterator[Issue] {
+	return newSearchIterator[Issue](c, "/search/issues", opts)
+}
+
+func newSearchIterator[T any](c *Client, endpoint string, opts SearchOptions) *SearchIterator[T] {
+	if opts.PerPage <= 0 {
+		opts.PerPage = 30 // padrão da API
+	}
+	start := opts.Page
+	if start <= 0 {
+		start = 1
+	}
+	return &SearchIterator[T]{client: c, endpoint: endpoint, opts: opts, next: start}
+}
+
+// Next busca a próxima página. Depois da última, devolve ErrNoMorePages.
+// Em caso de erro, a mesma página pode ser pedida de novo com outro Next.
+func (it *SearchIterator[T]) Next(ctx context.Context) (*Page[T], error) {
+	if it.next == 0 {
+		return nil, ErrNoMorePages
+	}
+
+	opts := it.opts
+	opts.Page = it.next
+	total, items, resp, err := searchPage[T](ctx, it.client, it.endpoint, opts)
+	if err != nil {
+		return nil, fmt.Errorf(tr("página %d: %w"), opts.Page, err)
+	}
+
+	page := &Page[T]{Number: opts.Page, Items: items, TotalCount: total, Rate: parseRate(resp.Header)}
+
+	// O header Link diz se há próxima página e quantas são; o GitHub já o
+	// limita à janela de 1000 resultados. Na última página não há next nem
+	// last.
+	links := ParsePageLinks(resp.Header)
+	page.LastPage = links.LastPage()
+	if next := links.NextPage(); next > opts.Page {
+		it.next = next
+		page.NextPage = next
+	} else {
+		it.next = 0
+		page.LastPage = opts.Page
+	}
+	return page, nil
+}
+
+// collect consome o iterador até reunir limit itens ou as páginas acabarem.
+// Um erro depois da primeira página devolve o que já foi coletado junto
+// com um *PartialError.
+func collect[T any](ctx context.Context, it *SearchIterator[T], limit int) (int, []T, error) {
+	return collectEach(ctx, it, limit, nil)
+}
+
+// collectEach é collect passando a emit cada página assim que ela chega,
+// já cortada em limit, para quem processa os itens enquanto as próximas
+// páginas ainda estão sendo buscadas (veja searchPipeline).
+func collectEach[T any](ctx context.Context, it *SearchIterator[T], limit int, emit func([]T)) (int, []T, error) {
+	if limit > maxSearchResults {
+		limit = maxSearchResults
+	}
+
+	prog := it.client.progressFor(ctx)
+	prog.Start(tr("busca"), 0, tr("páginas"))
+	defer prog.Finish()
+
+	var total, pages int
+	var all []T
+	for len(all) < limit {
+		page, err := it.Next(ctx)
+		if errors.Is(err, ErrNoMorePages) {
+			break
+		}
+		if err != nil && pages > 0 {
+			return total, all, &PartialError{Pages: pages, Items: len(all), Err: err}
+		}
+		if err != nil {
+			return 0, nil, err
+		}
+		pages++
+		total = page.TotalCount
+		items := page.Items[:min(len(page.Items), limit-len(all))]
+		all = append(all, items...)
+		if emit != nil {
+			emit(items)
+		}
+		// Só depois da primeira página se sabe quantas serão. Com
+		// SearchOptions.Page, as anteriores a ela não contam.
+		skipped := max(it.opts.Page-1, 0)
+		wanted := (min(limit, total-skipped*it.opts.PerPage, maxSearchResults-skipped*it.opts.PerPage) + it.opts.PerPage - 1) / it.opts.PerPage
+		if page.LastPage > 0 {
+			wanted = min(wanted, page.LastPage-skipped)
+		}
+		prog.SetTotal(wanted)
+		prog.Step(len(page.Items))
+	}
+	return total, all, nil
+}
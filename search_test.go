@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// bodyDoer responde qualquer requisição com o mesmo status, header Link e
+// corpo, para alimentar o decodificador com respostas arbitrárias.
+type bodyDoer struct {
+	status int
+	link   string
+	body   string
+}
+
+func (d bodyDoer) Do(req *http.Request) (*http.Response, error) {
+	header := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+	if d.link != "" {
+		header.Set("Link", d.link)
+	}
+	return (&cassette{StatusCode: d.status, Header: header, Body: d.body}).response(req), nil
+}
+
+func FuzzDecodeSearchResult(f *testing.F) {
+	for _, body := range []string{
+		`{"total_count":2,"incomplete_results":false,"items":[{"full_name":"a/b","stargazers_count":10,"owner":{"login":"a"},"license":{"key":"mit"},"topics":["cli"]},{"full_name":"c/d"}]}`,
+		`{"total_count":0,"items":[]}`,
+		`{"total_count":null,"items":null}`,
+		`{"items":[null,{}]}`,
+		`{"items":[{"owner":null,"license":null,"topics":null,"created_at":null}]}`,
+		`{"total_count":"10","items":{}}`,
+		`{"items":[{"stargazers_count":"muitas","created_at":"ontem","topics":"cli"}]}`,
+		`{"items":[{"license":"mit","owner":"a","score":"alta"}]}`,
+		`{"total_count":1e309}`,
+		`{"total_count":-5,"items":[{"stargazers_count":-1,"size":-1}]}`,
+		`{"items":[{"full_name":"\u0000\ud800","description":"\x1b[31m"}]}`,
+		`{"items":[`,
+		`[]`,
+		`null`,
+		``,
+		`<html>rate limited</html>`,
+	} {
+		f.Add(http.StatusOK, body, `<https://api.github.com/search/repositories?q=x&page=2>; rel="next"`)
+	}
+	f.Add(http.StatusUnprocessableEntity, `{"message":"Validation Failed","errors":[{"code":"invalid"}]}`, "")
+	f.Add(http.StatusForbidden, `{"message":"API rate limit exceeded"}`, "")
+	f.Add(http.StatusOK, `{"items":[]}`, `<>; rel="next", <https://x/?page=-1>; rel="last"`)
+
+	fields, err := parseFields(fieldNames())
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Fuzz(func(t *testing.T, status int, body, link string) {
+		if status < 100 || status > 599 {
+			return
+		}
+		c, err := NewClient(WithHTTPClient(bodyDoer{status: status, link: link, body: body}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.SetProgress(quietProgress())
+
+		it := c.RepositoryIterator(SearchOptions{Query: "language:go", PerPage: 30})
+		page, err := it.Next(context.Background())
+		if err != nil {
+			if page != nil {
+				t.Fatalf("Next devolveu página junto com o erro %v", err)
+			}
+			if status >= 200 && status < 300 {
+				return // JSON inválido ou de outro formato
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != status {
+				t.Fatalf("status %d virou %v, quer *APIError", status, err)
+			}
+			return
+		}
+		if status < 200 || status >= 300 {
+			t.Fatalf("status %d aceito como sucesso", status)
+		}
+		if page.NextPage != 0 && page.NextPage <= page.Number {
+			t.Fatalf("NextPage %d não avança a partir da página %d", page.NextPage, page.Number)
+		}
+
+		// O que decodificou tem de sair em todos os formatos sem pânico.
+		result := &SearchResult{TotalCount: page.TotalCount, Items: page.Items}
+		for _, format := range []string{formatText, formatTable, formatCSV, formatJSON} {
+			if err := writeResults(io.Discard, format, result, fields); err != nil {
+				t.Fatalf("writeResults(%s): %v", format, err)
+			}
+		}
+	})
+}
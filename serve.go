@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// server é o modo HTTP: um gateway para a busca (com o cache e o limite de
+// /search/* do Client) e para o histórico gravado pelo daemon.
+type server struct {
+	client  *Client
+	db      string        // banco SQLite de snapshots; vazio desliga /api/history
+	timeout time.Duration // prazo de cada busca (-timeout); 0 = o da conexão
+
+	widgetMaxAge time.Duration // validade de /widget/top nos caches de navegador e CDN
+
+	// tenants, quando presente (-multi-tenant), atende cada token recebido
+	// em Authorization com um Client próprio.
+	tenants *tenants
+}
+
+// runServe implementa `serve [-addr :8080] [-db snapshots.db]`.
+func runServe(args []string) error {
+	fs := newFlagSet("serve")
+	addr := fs.String("addr", ":8080", "endereço HTTP em que o servidor escuta")
+	db := fs.String("db", "snapshots.db", "banco SQLite com os snapshots do daemon, servido em /api/history")
+	widgetMaxAge := fs.Duration("widget-max-age", time.Hour, "por quanto tempo navegadores e CDNs podem guardar /widget/top")
+	multiTenant := fs.Bool("multi-tenant", false, "usa o token do header Authorization de cada requisição, com quota e cache separados por token")
+	maxTenants := fs.Int("max-tenants", defaultMaxTenants, "com -multi-tenant, quantos tokens manter ao mesmo tempo")
+	grace := fs.Duration("grace", defaultGracePeriod, "prazo para terminar as requisições em andamento depois de SIGINT/SIGTERM")
+	cf := addClientFlags(fs)
+	fs.Parse(args)
+
+	if *widgetMaxAge < 0 {
+		return invalid(errors.New(tr("-widget-max-age não pode ser negativo")))
+	}
+	if *maxTenants < 1 {
+		return invalid(errors.New(tr("-max-tenants deve ser positivo")))
+	}
+	if *grace < 0 {
+		return invalid(errors.New(tr("-grace não pode ser negativo")))
+	}
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	s := &server{client: client, db: *db, timeout: cf.runTimeout, widgetMaxAge: *widgetMaxAge}
+	if *multiTenant {
+		s.tenants = newTenants(*maxTenants, cf.newTenantClient)
+	}
+
+	stop, work, cancel := shutdownContexts(*grace)
+	defer cancel()
+	// As requisições herdam work: ao fim do prazo de -grace, as buscas que
+	// ainda estiverem no GitHub são canceladas.
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return work },
+	}
+	log.Printf(tr("serve: escutando em %s"), *addr)
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+	select {
+	case err := <-errc:
+		return err
+	case <-stop.Done():
+	}
+
+	// Shutdown fecha o listener e espera as requisições em andamento; work
+	// só é cancelado quando o prazo acaba.
+	if err := srv.Shutdown(work); err != nil {
+		srv.Close()
+		log.Print(tr("serve: prazo de encerramento esgotado; requisições em andamento interrompidas"))
+		return nil
+	}
+	log.Print(tr("serve: encerrado"))
+	return nil
+}
+
+// routes monta as rotas do servidor.
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/search", s.handleSearch)
+	mux.HandleFunc("GET /api/history/{owner}/{repo}", s.handleHistory)
+	mux.HandleFunc("GET /api/rate", s.handleRate)
+	mux.HandleFunc("GET /widget/top", s.handleWidgetTop)
+	mux.HandleFunc("OPTIONS /widget/top", handleWidgetPreflight)
+	return mux
+}
+
+// handleSearch responde GET /api/search?q=...&sort=...&order=...&limit=...&fields=...
+// no mesmo formato de -format json.
+func (s *server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	// Mesmos padrões da CLI: mais estrelados primeiro.
+	opts := SearchOptions{Query: q.Get("q"), Sort: "stars", Order: "desc"}
+	if v := q.Get("sort"); v != "" {
+		opts.Sort = v
+	}
+	if v := q.Get("order"); v != "" {
+		opts.Order = v
+	}
+	if opts.Query == "" {
+		writeHTTPError(w, http.StatusBadRequest, errors.New(tr("parâmetro q é obrigatório")))
+		return
+	}
+	if err := validateQuery(opts.Query); err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit := 30
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxSearchResults {
+			writeHTTPError(w, http.StatusBadRequest, fmt.Errorf(tr("limit deve estar entre 1 e %d"), maxSearchResults))
+			return
+		}
+		limit = n
+	}
+	opts.PerPage = min(limit, maxPerPage)
+	fieldsSpec := defaultFields
+	if v := q.Get("fields"); v != "" {
+		fieldsSpec = v
+	}
+	fields, err := parseFields(fieldsSpec)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	client, _, ok := s.clientFor(w, r)
+	if !ok {
+		return
+	}
+	ctx, cancel := withTimeout(r.Context(), s.timeout)
+	defer cancel()
+	result, err := client.SearchAllRepositories(ctx, opts, limit)
+	if err != nil {
+		writeHTTPError(w, upstreamStatus(err), err)
+		return
+	}
+	writeHTTPJSON(w, http.StatusOK, newJSONResult(result, fields))
+}
+
+// clientFor escolhe o Client da requisição: com -multi-tenant e um header
+// Authorization, o do tenant; senão, o do servidor. Um header malformado
+// responde 401 e devolve ok false.
+func (s *server) clientFor(w http.ResponseWriter, r *http.Request) (client *Client, tenant string, ok bool) {
+	if s.tenants == nil {
+		return s.client, "", true
+	}
+	// A resposta depende do token: caches no caminho não podem misturar.
+	w.Header().Add("Vary", "Authorization")
+	token, found, err := tenantToken(r)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="ghsearch"`)
+		writeHTTPError(w, http.StatusUnauthorized, err)
+		return nil, "", false
+	}
+	if !found {
+		return s.client, "", true
+	}
+	client, tenant, err = s.tenants.client(token)
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err)
+		return nil, "", false
+	}
+	w.Header().Set("X-Ghsearch-Tenant", tenant)
+	return client, tenant, true
+}
+
+// handleRate responde GET /api/rate com o consumo e a última quota vista
+// do token da requisição, sem gastar chamadas.
+func (s *server) handleRate(w http.ResponseWriter, r *http.Request) {
+	client, tenant, ok := s.clientFor(w, r)
+	if !ok {
+		return
+	}
+	writeHTTPJSON(w, http.StatusOK, client.tenantUsage(tenant))
+}
+
+// historyResponse é o corpo de /api/history/{owner}/{repo}.
+type historyResponse struct {
+	Repo   string         `json:"repo"`
+	Points []historyPoint `json:"points"`
+}
+
+// handleHistory responde GET /api/history/{owner}/{repo}[?since=90d] com a
+// série de estrelas e forks gravada no banco de snapshots.
+func (s *server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	repo := r.PathValue("owner") + "/" + r.PathValue("repo")
+	since, err := parseAge(r.URL.Query().Get("since"))
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+	if _, err := os.Stat(s.db); err != nil {
+		writeHTTPError(w, http.StatusServiceUnavailable, errors.New(tr("banco de snapshots indisponível")))
+		return
+	}
+
+	points, err := loadHistory(r.Context(), s.db, repo)
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if since > 0 {
+		cutoff := time.Now().Add(-since).UTC().Format(time.RFC3339)
+		kept := points[:0]
+		for _, p := range points {
+			if p.TakenAt >= cutoff {
+				kept = append(kept, p)
+			}
+		}
+		points = kept
+	}
+	if len(points) == 0 {
+		writeHTTPError(w, http.StatusNotFound, fmt.Errorf(tr("nenhum snapshot de %s"), repo))
+		return
+	}
+	writeHTTPJSON(w, http.StatusOK, historyResponse{Repo: repo, Points: points})
+}
+
+// upstreamStatus escolhe o status HTTP para um erro vindo da API do GitHub.
+func upstreamStatus(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity {
+		return http.StatusBadRequest // query inválida
+	}
+	if isStatus(err, http.StatusUnauthorized) {
+		return http.StatusUnauthorized // token do tenant recusado pelo GitHub
+	}
+	return http.StatusBadGateway
+}
+
+func writeHTTPJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Printf(tr("serve: falha ao escrever resposta: %v"), err)
+	}
+}
+
+func writeHTTPError(w http.ResponseWriter, status int, err error) {
+	writeHTTPJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// defaultGracePeriod é quanto serve e daemon esperam o trabalho em
+// andamento terminar depois de um SIGINT/SIGTERM.
+const defaultGracePeriod = 30 * time.Second
+
+// shutdownContexts prepara o encerramento gracioso dos modos de longa
+// duração. stop é cancelado no primeiro SIGINT/SIGTERM: a partir dele,
+// nada novo começa. work, o contexto do trabalho em andamento (requisições
+// ao GitHub, escrita nos sinks), só é cancelado grace depois, para dar
+// tempo de terminar o que já começou. Um segundo sinal encerra o processo
+// na hora.
+func shutdownContexts(grace time.Duration) (stop, work context.Context, cancel func()) {
+	stop, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	work, cancelWork := context.WithCancel(context.WithoutCancel(stop))
+	after := context.AfterFunc(stop, func() {
+		stopSignals() // o próximo sinal volta ao comportamento padrão
+		log.Printf(tr("encerrando: terminando o trabalho em andamento (até %s; outro sinal encerra já)"), grace)
+		timer := time.AfterFunc(grace, cancelWork)
+		context.AfterFunc(work, func() { timer.Stop() })
+	})
+	return stop, work, func() {
+		after()
+		stopSignals()
+		cancelWork()
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Sink é um destino para os resultados de uma busca. Daemon e busca
+// entregam snapshots a qualquer Sink sem saber para onde eles vão.
+// As implementações também são fmt.Stringer, para as mensagens de erro.
+type Sink interface {
+	Write(ctx context.Context, snap snapshot) error
+}
+
+// parseSink interpreta a especificação de -sink:
+//
+//	stdout                  uma linha JSON por snapshot na saída padrão
+//	file:<caminho>          JSON Lines, um snapshot por linha
+//	sqlite:<caminho>        tabela snapshots no banco SQLite
+//	webhook:<url>           POST com o snapshot em JSON
+//	s3://<bucket>/<prefixo> um objeto JSON por snapshot (S3 ou compatível)
+func parseSink(spec string) (Sink, error) {
+	if spec == "stdout" {
+		return writerSink{os.Stdout}, nil
+	}
+	if strings.HasPrefix(spec, "s3://") {
+		return newS3Sink(spec)
+	}
+	kind, target, ok := strings.Cut(spec, ":")
+	if !ok || target == "" {
+		return nil, fmt.Errorf(tr("sink inválido %q (use stdout, file:<caminho>, sqlite:<caminho>, webhook:<url> ou s3://<bucket>/<prefixo>)"), spec)
+	}
+	switch kind {
+	case "file":
+		return fileSink{path: target}, nil
+	case "sqlite":
+		return sqliteSink{path: target}, nil
+	case "webhook":
+		return webhookSink{url: target, client: &http.Client{Timeout: 10 * time.Second}}, nil
+	}
+	return nil, fmt.Errorf(tr("tipo de sink desconhecido %q"), kind)
+}
+
+// sinkList permite repetir -sink na linha de comando.
+type sinkList []Sink
+
+func (l *sinkList) String() string { return fmt.Sprintf("%d sinks", len(*l)) }
+
+func (l *sinkList) Set(spec string) error {
+	s, err := parseSink(spec)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, s)
+	return nil
+}
+
+// writeAll entrega o snapshot a todos os sinks; a falha de um não impede
+// os demais.
+func (l sinkList) writeAll(ctx context.Context, snap snapshot) error {
+	var errs []error
+	for _, s := range l {
+		if err := s.Write(ctx, snap); err != nil {
+			errs = append(errs, fmt.Errorf("sink %v: %w", s, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// writerSink escreve o snapshot como uma linha JSON em um io.Writer.
+type writerSink struct {
+	w io.Writer
+}
+
+func (s writerSink) String() string { return "stdout" }
+
+func (s writerSink) Write(_ context.Context, snap snapshot) error {
+	return json.NewEncoder(s.w).Encode(snap)
+}
+
+// fileSink acrescenta o snapshot como uma linha JSON ao arquivo.
+type fileSink struct {
+	path string
+}
+
+func (s fileSink) String() string { return "file:" + s.path }
+
+func (s fileSink) Write(_ context.Context, snap snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf(tr("falha ao codificar snapshot: %w"), err)
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf(tr("falha ao abrir %s: %w"), s.path, err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf(tr("falha ao gravar %s: %w"), s.path, err)
+	}
+	return f.Close()
+}
+
+// sqliteSink grava o snapshot na tabela snapshots.
+type sqliteSink struct {
+	path string
+}
+
+func (s sqliteSink) String() string { return "sqlite:" + s.path }
+
+func (s sqliteSink) Write(ctx context.Context, snap snapshot) error {
+	return saveSnapshotSQLite(ctx, s.path, snap)
+}
+
+// webhookSink envia o snapshot em JSON via POST.
+type webhookSink struct {
+	url    string
+	client Doer
+}
+
+func (s webhookSink) String() string { return "webhook:" + s.url }
+
+func (s webhookSink) Write(ctx context.Context, snap snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf(tr("falha ao codificar snapshot: %w"), err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf(tr("falha ao criar requisição: %w"), err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", defaultUserAgent())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf(tr("falha ao executar requisição: %w"), err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf(tr("webhook retornou status não-OK: %s"), resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// snapshot é o resultado de uma busca em um instante, no formato gravado
+// pelo daemon (arquivo JSON Lines, webhook) e lido de volta por outros
+// comandos.
+type snapshot struct {
+	Job        string       `json:"job,omitempty"`
+	Query      string       `json:"query"`
+	TakenAt    time.Time    `json:"taken_at"`
+	TotalCount int          `json:"total_count"`
+	Items      []Repository `json:"items"`
+}
+
+// newSnapshot registra o resultado de uma query no instante atual.
+func newSnapshot(query string, result *SearchResult) snapshot {
+	return snapshot{Query: query, TakenAt: time.Now().UTC(), TotalCount: result.TotalCount, Items: result.Items}
+}
+
+// snapshotSchema cria a tabela de snapshots no SQLite: uma linha por
+// repositório por coleta, o que permite montar séries temporais.
+const snapshotSchema = `CREATE TABLE IF NOT EXISTS snapshots (
+	taken_at    TEXT    NOT NULL,
+	job         TEXT    NOT NULL,
+	query       TEXT    NOT NULL,
+	full_name   TEXT    NOT NULL,
+	stars       INTEGER NOT NULL,
+	forks       INTEGER NOT NULL,
+	open_issues INTEGER NOT NULL,
+	pushed_at   TEXT
+);
+CREATE INDEX IF NOT EXISTS snapshots_repo ON snapshots (full_name, taken_at);
+`
+
+// saveSnapshotSQLite grava o snapshot no banco em path numa única transação.
+func saveSnapshotSQLite(ctx context.Context, path string, snap snapshot) error {
+	var sql strings.Builder
+	sql.WriteString(snapshotSchema)
+	sql.WriteString("BEGIN;\n")
+	takenAt := sqlQuote(snap.TakenAt.UTC().Format(time.RFC3339))
+	for _, r := range snap.Items {
+		pushed := "NULL"
+		if !r.PushedAt.IsZero() {
+			pushed = sqlQuote(r.PushedAt.UTC().Format(time.RFC3339))
+		}
+		fmt.Fprintf(&sql, "INSERT INTO snapshots VALUES (%s, %s, %s, %s, %s, %s, %s, %s);\n",
+			takenAt, sqlQuote(snap.Job), sqlQuote(snap.Query), sqlQuote(r.FullName),
+			sqlInt(r.Stars), sqlInt(r.Forks), sqlInt(r.OpenIssues), pushed)
+	}
+	sql.WriteString("COMMIT;\n")
+	return sqliteExec(ctx, path, sql.String())
+}
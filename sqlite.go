@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// O projeto não tem dependências externas, então o SQLite é acessado pelo
+// binário sqlite3 (presente por padrão no macOS e na maioria das
+// distribuições Linux). Os comandos SQL vão pelo stdin; as consultas usam
+// o modo -json do sqlite3 (3.33+).
+
+// errNoSQLite indica que o binário sqlite3 não está no PATH.
+var errNoSQLite error = trError("sqlite3 não encontrado no PATH; instale o SQLite para usar o armazenamento local")
+
+// sqliteExec executa comandos SQL no banco em path. Cancelar ctx mata o
+// sqlite3, para que um banco travado não segure o encerramento.
+func sqliteExec(ctx context.Context, path, sql string) error {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		return errNoSQLite
+	}
+	cmd := exec.CommandContext(ctx, "sqlite3", "-bail", path)
+	cmd.Stdin = strings.NewReader(sql)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sqlite3: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// sqliteQuery executa uma consulta e decodifica as linhas em v (um
+// ponteiro para slice de structs com tags json iguais às colunas).
+func sqliteQuery(ctx context.Context, path, sql string, v any) error {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		return errNoSQLite
+	}
+	cmd := exec.CommandContext(ctx, "sqlite3", "-bail", "-json", path)
+	cmd.Stdin = strings.NewReader(sql)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("sqlite3: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	// Sem linhas, o sqlite3 não imprime nada (nem "[]").
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return json.Unmarshal([]byte("[]"), v)
+	}
+	if err := json.Unmarshal(stdout.Bytes(), v); err != nil {
+		return fmt.Errorf(tr("sqlite3: falha ao decodificar resultado: %w"), err)
+	}
+	return nil
+}
+
+// sqlQuote escapa um texto como literal SQL.
+func sqlQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// sqlInt formata um inteiro como literal SQL.
+func sqlInt(n int) string {
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// starMediaType faz /user/starred devolver, junto de cada repositório, a
+// data em que ele recebeu a estrela.
+const starMediaType = "application/vnd.github.star+json"
+
+// Ordenações aceitas por `starred -sort`.
+var starredSorts = map[string]func(a, b Repository) bool{
+	"starred": func(a, b Repository) bool { return a.StarredAt.After(*b.StarredAt) },
+	"stars":   func(a, b Repository) bool { return a.Stars > b.Stars },
+	"pushed":  func(a, b Repository) bool { return a.PushedAt.After(b.PushedAt) },
+	"name":    func(a, b Repository) bool { return strings.ToLower(a.FullName) < strings.ToLower(b.FullName) },
+}
+
+// ListStarred lista até limit repositórios com estrela do usuário
+// autenticado, das estrelas mais recentes para as mais antigas, com
+// StarredAt preenchido.
+func (c *Client) ListStarred(ctx context.Context, limit int) ([]Repository, error) {
+	type star struct {
+		StarredAt time.Time  `json:"starred_at"`
+		Repo      Repository `json:"repo"`
+	}
+	params := url.Values{"sort": {"created"}, "direction": {"desc"}}
+	stars, err := listAll[star](ctx, c, "/user/starred", params, limit, func(req *http.Request) {
+		req.Header.Set("Accept", starMediaType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	all := make([]Repository, len(stars))
+	for i, s := range stars {
+		all[i] = s.Repo
+		all[i].StarredAt = &stars[i].StarredAt
+	}
+	return all, nil
+}
+
+// starredFilter descarta, no cliente, repositórios com estrela pela
+// linguagem, pelos tópicos e por um texto no nome ou na descrição.
+type starredFilter struct {
+	Language string
+	Topics   topicFilter
+	Match    string
+}
+
+func (f starredFilter) keep(r Repository) bool {
+	if f.Language != "" && !strings.EqualFold(r.Language, f.Language) {
+		return false
+	}
+	if f.Match != "" {
+		text := strings.ToLower(r.FullName + " " + r.Description)
+		if !strings.Contains(text, strings.ToLower(f.Match)) {
+			return false
+		}
+	}
+	return f.Topics.keep(r)
+}
+
+// markStarred preenche StarredAt nos repositórios da busca que já têm
+// estrela, para que a coluna starred_at mostre quais são novidade.
+func markStarred(repos, starred []Repository) int {
+	at := map[string]*time.Time{}
+	for _, s := range starred {
+		at[strings.ToLower(s.FullName)] = s.StarredAt
+	}
+	marked := 0
+	for i := range repos {
+		if t, ok := at[strings.ToLower(repos[i].FullName)]; ok {
+			repos[i].StarredAt = t
+			marked++
+		}
+	}
+	return marked
+}
+
+// runStarred implementa `starred`: lista os repositórios com estrela do
+// usuário autenticado, com filtros e ordenação locais. Com -against, mostra
+// em vez disso os resultados de uma busca, marcando os que já têm estrela.
+func runStarred(args []string) error {
+	fs := newFlagSet("starred")
+	limit := fs.Int("limit", 1000, "máximo de repositórios com estrela lidos (as estrelas mais recentes primeiro)")
+	language := fs.String("language", "", "mantém só repositórios desta linguagem")
+	topic := fs.String("topic", "", "mantém só repositórios com todos estes tópicos, separados por vírgula")
+	match := fs.String("match", "", "mantém só repositórios com este texto no nome ou na descrição")
+	sortBy := fs.String("sort", "starred", "ordenação: starred (estrela mais recente), stars, pushed ou name")
+	against := fs.String("against", "", "query de busca cujos resultados são comparados com as estrelas")
+	againstLimit := fs.Int("against-limit", 30, "resultados da busca de -against")
+	format := fs.String("format", formatTable, "formato de saída: text, table, csv ou json")
+	fieldsSpec := fs.String("fields", "full_name,stars,language,starred_at,url", "colunas para table/csv/json, separadas por vírgula")
+	cf := addClientFlags(fs)
+	fs.Parse(args)
+
+	less, ok := starredSorts[*sortBy]
+	if !ok {
+		return invalid(fmt.Errorf(tr("-sort aceita starred, stars, pushed ou name, recebido %q"), *sortBy))
+	}
+	if *limit < 1 {
+		return invalid(errors.New(tr("-limit deve ser positivo")))
+	}
+	if *against != "" {
+		if err := validateQuery(*against); err != nil {
+			return err
+		}
+		if *againstLimit < 1 || *againstLimit > maxSearchResults {
+			return invalid(fmt.Errorf(tr("-against-limit deve estar entre 1 e %d"), maxSearchResults))
+		}
+	}
+	fields, err := parseFields(*fieldsSpec)
+	if err != nil {
+		return err
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+	if client.token == "" && !cf.offline() {
+		return needsAuth(errors.New(tr("listar estrelas exige autenticação: configure GITHUB_TOKEN ou rode `login`")))
+	}
+	ctx, cancel := cf.context()
+	defer cancel()
+
+	starred, err := client.ListStarred(ctx, *limit)
+	if err != nil {
+		return err
+	}
+
+	if *against != "" {
+		opts := SearchOptions{Query: *against, Sort: "stars", Order: "desc", PerPage: min(*againstLimit, maxPerPage)}
+		result, err := client.SearchAllRepositories(ctx, opts, *againstLimit)
+		if err != nil {
+			return err
+		}
+		marked := markStarred(result.Items, starred)
+		if *format == formatText || *format == formatTable {
+			fmt.Printf(tr("%d dos %d resultados já têm estrela.\n\n"), marked, len(result.Items))
+		}
+		return writeResults(os.Stdout, *format, result, fields)
+	}
+
+	filter := starredFilter{Language: *language, Topics: topicFilter{Include: parseTopics(*topic)}, Match: *match}
+	kept := starred[:0]
+	for _, r := range starred {
+		if filter.keep(r) {
+			kept = append(kept, r)
+		}
+	}
+	sort.SliceStable(kept, func(a, b int) bool { return less(kept[a], kept[b]) })
+	return writeResults(os.Stdout, *format, &SearchResult{TotalCount: len(kept), Items: kept}, fields)
+}
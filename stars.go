@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Star marca o repositório com estrela para o usuário autenticado. Marcar
+// de novo um repositório já marcado não é erro.
+func (c *Client) Star(ctx context.Context, fullName string) error {
+	return c.starred(ctx, http.MethodPut, fullName)
+}
+
+// Unstar tira a estrela do repositório. Tirar de um repositório sem
+// estrela também não é erro.
+func (c *Client) Unstar(ctx context.Context, fullName string) error {
+	return c.starred(ctx, http.MethodDelete, fullName)
+}
+
+func (c *Client) starred(ctx context.Context, method, fullName string) error {
+	req, err := c.newRequest(ctx, method, "/user/starred/"+fullName, nil)
+	if err != nil {
+		return err
+	}
+	// Sem corpo, o net/http já manda o Content-Length: 0 que a API pede
+	// no PUT.
+	_, err = c.do(req, nil)
+	if isStatus(err, http.StatusNotFound) {
+		// Repositório inexistente ou token sem o escopo de estrelas.
+		return fmt.Errorf(tr("%s não encontrado (confira o nome e se o token permite marcar estrelas): %w"), fullName, err)
+	}
+	return err
+}
+
+// starTop aplica star (ou unstar) aos n primeiros repositórios, na ordem
+// mostrada, e relata cada um em w. Para no primeiro erro.
+func starTop(ctx context.Context, client *Client, w io.Writer, repos []Repository, n int, unstar bool) error {
+	for _, r := range repos[:min(n, len(repos))] {
+		if unstar {
+			if err := client.Unstar(ctx, r.FullName); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, tr("☆ estrela removida: %s\n"), r.FullName)
+			continue
+		}
+		if err := client.Star(ctx, r.FullName); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, tr("⭐ estrela marcada: %s\n"), r.FullName)
+	}
+	return nil
+}
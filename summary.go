@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheHitHeader marca as respostas servidas pelo cache, para que o
+// Client as conte à parte e não leia delas uma quota desatualizada.
+const cacheHitHeader = "X-Ghsearch-Cache"
+
+// clientUsage acumula o uso da API nesta execução: requisições, acertos
+// de cache e a última quota vista de cada recurso.
+type clientUsage struct {
+	mu        sync.Mutex
+	requests  int
+	cacheHits int
+	core      RateBucket
+	search    RateBucket
+}
+
+// observe registra uma resposta recebida para req.
+func (u *clientUsage) observe(req *http.Request, resp *http.Response, rate RateBucket) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.requests++
+	if resp.Header.Get(cacheHitHeader) != "" {
+		u.cacheHits++
+		return
+	}
+	if rate.Limit == 0 {
+		return
+	}
+	if strings.Contains(req.URL.Path, "/search/") {
+		u.search = rate
+	} else {
+		u.core = rate
+	}
+}
+
+// runMeta é o resumo de uma execução, mostrado no rodapé da saída humana
+// e como objeto meta no JSON.
+type runMeta struct {
+	TotalCount      int   `json:"total_count"`
+	Shown           int   `json:"shown"`
+	APICalls        int   `json:"api_calls"`
+	CacheHits       int   `json:"cache_hits"`
+	CloneSizeKB     int   `json:"clone_size_kb"`              // soma do size dos itens mostrados
+	CoreRemaining   *int  `json:"core_remaining,omitempty"`   // nil se nenhuma resposta trouxe a quota
+	SearchRemaining *int  `json:"search_remaining,omitempty"` // idem, para a busca
+	ElapsedMS       int64 `json:"elapsed_ms"`
+}
+
+// runMeta resume o uso do Client desde start para o resultado mostrado.
+// APICalls conta só as requisições que foram à rede.
+func (c *Client) runMeta(result *SearchResult, start time.Time) *runMeta {
+	c.usage.mu.Lock()
+	defer c.usage.mu.Unlock()
+	m := &runMeta{
+		TotalCount: result.TotalCount,
+		Shown:      len(result.Items),
+		APICalls:   c.usage.requests - c.usage.cacheHits,
+		CacheHits:  c.usage.cacheHits,
+		ElapsedMS:  time.Since(start).Milliseconds(),
+	}
+	for _, r := range result.Items {
+		m.CloneSizeKB += r.Size
+	}
+	if b := c.usage.core; b.Limit > 0 {
+		m.CoreRemaining = &b.Remaining
+	}
+	if b := c.usage.search; b.Limit > 0 {
+		m.SearchRemaining = &b.Remaining
+	}
+	return m
+}
+
+// writeSummary escreve o rodapé com o resumo da execução.
+func writeSummary(w io.Writer, m *runMeta) {
+	parts := []string{
+		fmt.Sprintf(tr("%d encontrados"), m.TotalCount),
+		fmt.Sprintf(tr("%d mostrados"), m.Shown),
+		fmt.Sprintf(tr("%d chamadas à API"), m.APICalls),
+		fmt.Sprintf(tr("%d do cache"), m.CacheHits),
+	}
+	var quota []string
+	if m.CoreRemaining != nil {
+		quota = append(quota, fmt.Sprintf("core %d", *m.CoreRemaining))
+	}
+	if m.SearchRemaining != nil {
+		quota = append(quota, fmt.Sprintf(tr("busca %d"), *m.SearchRemaining))
+	}
+	if len(quota) > 0 {
+		parts = append(parts, tr("quota restante: ")+strings.Join(quota, ", "))
+	}
+	parts = append(parts, fmt.Sprintf(tr("%s para clonar"), formatSize(m.CloneSizeKB)))
+	parts = append(parts, (time.Duration(m.ElapsedMS) * time.Millisecond).String())
+	fmt.Fprintf(w, "— %s\n", strings.Join(parts, " · "))
+}
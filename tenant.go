@@ -0,0 +1,115 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMaxTenants é quantos tenants o serve mantém ao mesmo tempo.
+const defaultMaxTenants = 100
+
+// tenants são os clientes do serve multi-tenant: um Client por token
+// recebido no header Authorization, cada um com o próprio limitador de
+// busca, a própria contagem de quota e o cache numa partição só dele. O
+// token do tenant vai para o GitHub no lugar do token do servidor.
+//
+// Acima de max tenants, o usado há mais tempo é descartado (e recriado, do
+// zero, se voltar), para que tokens inventados não cresçam a memória sem
+// limite.
+type tenants struct {
+	mu      sync.Mutex
+	clients map[string]*tenant
+	max     int
+	build   func(token, id string) (*Client, error)
+}
+
+type tenant struct {
+	client   *Client
+	lastUsed time.Time
+}
+
+func newTenants(max int, build func(token, id string) (*Client, error)) *tenants {
+	return &tenants{clients: map[string]*tenant{}, max: max, build: build}
+}
+
+// tenantID identifica o tenant pelo token sem guardá-lo: é o nome da
+// partição de cache e o que aparece em logs e em /api/rate.
+func tenantID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
+// tenantToken lê o token do header Authorization, nos dois formatos que o
+// GitHub aceita ("Bearer x" e "token x"). Sem header, ok é false.
+func tenantToken(r *http.Request) (token string, ok bool, err error) {
+	h := strings.TrimSpace(r.Header.Get("Authorization"))
+	if h == "" {
+		return "", false, nil
+	}
+	scheme, token, _ := strings.Cut(h, " ")
+	token = strings.TrimSpace(token)
+	if token == "" || !strings.EqualFold(scheme, "bearer") && !strings.EqualFold(scheme, "token") {
+		return "", false, errors.New(tr("header Authorization inválido: use \"Bearer <token>\""))
+	}
+	return token, true, nil
+}
+
+// client devolve o Client do tenant dono de token, criando-o no primeiro uso.
+func (t *tenants) client(token string) (*Client, string, error) {
+	id := tenantID(token)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if tn, ok := t.clients[id]; ok {
+		tn.lastUsed = time.Now()
+		return tn.client, id, nil
+	}
+
+	c, err := t.build(token, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(t.clients) >= t.max {
+		t.evictOldest()
+	}
+	t.clients[id] = &tenant{client: c, lastUsed: time.Now()}
+	return c, id, nil
+}
+
+// evictOldest descarta o tenant usado há mais tempo. Chamado com mu preso.
+func (t *tenants) evictOldest() {
+	var oldest string
+	for id, tn := range t.clients {
+		if oldest == "" || tn.lastUsed.Before(t.clients[oldest].lastUsed) {
+			oldest = id
+		}
+	}
+	delete(t.clients, oldest)
+}
+
+// tenantUsage é o corpo de /api/rate: o consumo e a última quota vista do
+// tenant da requisição (ou do token do servidor, com tenant vazio).
+type tenantUsage struct {
+	Tenant    string      `json:"tenant,omitempty"`
+	APICalls  int         `json:"api_calls"`
+	CacheHits int         `json:"cache_hits"`
+	Core      *RateBucket `json:"core,omitempty"`
+	Search    *RateBucket `json:"search,omitempty"`
+}
+
+func (c *Client) tenantUsage(id string) tenantUsage {
+	c.usage.mu.Lock()
+	defer c.usage.mu.Unlock()
+	u := tenantUsage{Tenant: id, APICalls: c.usage.requests - c.usage.cacheHits, CacheHits: c.usage.cacheHits}
+	if b := c.usage.core; b.Limit > 0 {
+		u.Core = &b
+	}
+	if b := c.usage.search; b.Limit > 0 {
+		u.Search = &b
+	}
+	return u
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Os prazos do Client são aplicados por contexto, em três níveis: cada
+// tentativa de requisição (WithTimeout), cada página da busca, incluindo a
+// espera do limitador e as repetições (WithSearchTimeout), e cada etapa de
+// enriquecimento de um repositório (WithEnrichTimeout). O prazo da execução
+// inteira fica com quem chama, no ctx recebido.
+
+// WithSearchTimeout limita cada página da busca, da espera pelo limitador
+// por minuto até o fim do corpo; 0 deixa só o limite por requisição.
+func WithSearchTimeout(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d < 0 {
+			return errors.New(tr("o timeout não pode ser negativo"))
+		}
+		c.searchTimeout = d
+		return nil
+	}
+}
+
+// WithEnrichTimeout limita cada etapa de enriquecimento de um repositório,
+// com todas as chamadas e esperas dela (o heatmap, por exemplo, espera o
+// GitHub calcular as estatísticas); 0 deixa só o limite por requisição.
+// Um enriquecimento que estoura o prazo fica indisponível só naquele
+// repositório.
+func WithEnrichTimeout(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d < 0 {
+			return errors.New(tr("o timeout não pode ser negativo"))
+		}
+		c.enrichTimeout = d
+		return nil
+	}
+}
+
+// withTimeout é context.WithTimeout que aceita 0 como "sem prazo".
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// attempt prepara uma tentativa de req com o prazo por requisição. O
+// prazo vale até o corpo ser fechado, não só até os headers chegarem.
+func (c *Client) attempt(req *http.Request) (*http.Request, context.CancelFunc) {
+	ctx, cancel := withTimeout(req.Context(), c.timeout)
+	return req.WithContext(ctx), cancel
+}
+
+// timeoutError explica o erro de uma tentativa que estourou o prazo por
+// requisição, que de outra forma seria só "context deadline exceeded".
+func (c *Client) timeoutError(parent context.Context, err error) error {
+	if err != nil && errors.Is(err, context.DeadlineExceeded) && parent.Err() == nil {
+		return fmt.Errorf(tr("sem resposta em %s: %w"), c.timeout, err)
+	}
+	return err
+}
+
+// cancelOnClose libera o prazo de uma tentativa quando o corpo é fechado.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
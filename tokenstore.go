@@ -0,0 +1,194 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// appName identifica a aplicação no keychain e no diretório de configuração.
+const appName = "ghsearch"
+
+// errNoToken indica que nenhum token foi salvo ainda.
+var errNoToken error = trError("nenhum token salvo; execute o subcomando login")
+
+// configDir devolve (e cria, se necessário) o diretório de configuração
+// da aplicação, ex: ~/.config/ghsearch no Linux.
+func configDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf(tr("falha ao localizar diretório de configuração: %w"), err)
+	}
+	dir := filepath.Join(base, appName)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf(tr("falha ao criar diretório de configuração: %w"), err)
+	}
+	return dir, nil
+}
+
+// saveToken guarda o token no keychain do sistema operacional quando
+// disponível; caso contrário, em um arquivo criptografado (AES-GCM).
+// Devolve uma descrição de onde o token foi salvo.
+func saveToken(token string) (string, error) {
+	if err := keychainSet(token); err == nil {
+		return tr("keychain do sistema"), nil
+	}
+	path, err := fileTokenSet(token)
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// loadToken recupera o token salvo por saveToken.
+func loadToken() (string, error) {
+	if token, err := keychainGet(); err == nil && token != "" {
+		return token, nil
+	}
+	return fileTokenGet()
+}
+
+// --- Keychain ---
+// Usamos as ferramentas de linha de comando do próprio sistema
+// (security no macOS, secret-tool no Linux) para não depender de cgo.
+
+func keychainSet(token string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		// Com -w na linha de comando, o token ficaria visível para qualquer
+		// usuário no ps; no modo interativo (-i), o comando vem pelo stdin.
+		if strings.ContainsAny(token, "\" \\\t\r\n") {
+			return errors.New(tr("token com caracteres inválidos"))
+		}
+		cmd := exec.Command("security", "-i")
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a github -w \"%s\"\n", appName, token))
+		// No modo interativo o status de saída não reflete o comando: a
+		// falha só aparece no stderr.
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return err
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+		return nil
+	case "linux":
+		cmd := exec.Command("secret-tool", "store", "--label="+appName,
+			"service", appName, "account", "github")
+		cmd.Stdin = strings.NewReader(token)
+		return cmd.Run()
+	}
+	return fmt.Errorf(tr("keychain não suportado em %s"), runtime.GOOS)
+}
+
+func keychainGet() (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", appName, "-a", "github", "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", appName, "account", "github")
+	default:
+		return "", fmt.Errorf(tr("keychain não suportado em %s"), runtime.GOOS)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// --- Arquivo criptografado ---
+// A chave AES é aleatória e fica em um arquivo separado com permissão 0600.
+// Isso evita que o token apareça em texto puro em backups ou em um `cat`
+// acidental, mas não protege contra alguém com acesso total à conta.
+
+func tokenFileKey(dir string) ([]byte, error) {
+	keyPath := filepath.Join(dir, "token.key")
+	key, err := os.ReadFile(keyPath)
+	if err == nil && len(key) == 32 {
+		return key, nil
+	}
+	key = make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf(tr("falha ao gerar chave: %w"), err)
+	}
+	if err := os.WriteFile(keyPath, key, 0o600); err != nil {
+		return nil, fmt.Errorf(tr("falha ao salvar chave: %w"), err)
+	}
+	return key, nil
+}
+
+func fileTokenSet(token string) (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	key, err := tokenFileKey(dir)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf(tr("falha ao gerar nonce: %w"), err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(token), nil)
+
+	path := filepath.Join(dir, "token.enc")
+	if err := os.WriteFile(path, sealed, 0o600); err != nil {
+		return "", fmt.Errorf(tr("falha ao salvar token: %w"), err)
+	}
+	return path, nil
+}
+
+func fileTokenGet() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := os.ReadFile(filepath.Join(dir, "token.enc"))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", errNoToken
+	}
+	if err != nil {
+		return "", fmt.Errorf(tr("falha ao ler token: %w"), err)
+	}
+	key, err := os.ReadFile(filepath.Join(dir, "token.key"))
+	if err != nil {
+		return "", fmt.Errorf(tr("falha ao ler chave: %w"), err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New(tr("arquivo de token corrompido"))
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf(tr("falha ao descriptografar token: %w"), err)
+	}
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf(tr("falha ao inicializar cifra: %w"), err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// topicsMediaType faz a busca de repositórios incluir o campo topics em
+// cada item.
+const topicsMediaType = "application/vnd.github.mercy-preview+json"
+
+// topicFilter descarta, no cliente, repositórios pelos tópicos: ficam os
+// que têm todos os Include e nenhum dos Exclude. Listas vazias não filtram.
+type topicFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// parseTopics converte "cli,web" em tópicos normalizados (o GitHub os
+// guarda em minúsculas).
+func parseTopics(spec string) []string {
+	var topics []string
+	for _, t := range strings.Split(spec, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			topics = append(topics, t)
+		}
+	}
+	return topics
+}
+
+func (f topicFilter) keep(r Repository) bool {
+	has := map[string]bool{}
+	for _, t := range r.Topics {
+		has[t] = true
+	}
+	for _, t := range f.Include {
+		if !has[t] {
+			return false
+		}
+	}
+	for _, t := range f.Exclude {
+		if has[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// filterByTopic devolve apenas os repositórios aceitos pelo filtro.
+func filterByTopic(repos []Repository, f topicFilter) []Repository {
+	if len(f.Include) == 0 && len(f.Exclude) == 0 {
+		return repos
+	}
+	kept := repos[:0:0]
+	for _, r := range repos {
+		if f.keep(r) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// topicCount é a frequência de um tópico no resultado.
+type topicCount struct {
+	Topic string `json:"topic"`
+	Repos int    `json:"repos"`
+}
+
+// topicCloud conta em quantos repositórios cada tópico aparece e devolve
+// os top mais frequentes (empates em ordem alfabética).
+func topicCloud(repos []Repository, top int) []topicCount {
+	counts := map[string]int{}
+	for _, r := range repos {
+		for _, t := range r.Topics {
+			counts[t]++
+		}
+	}
+	cloud := make([]topicCount, 0, len(counts))
+	for t, n := range counts {
+		cloud = append(cloud, topicCount{t, n})
+	}
+	sort.Slice(cloud, func(a, b int) bool {
+		if cloud[a].Repos != cloud[b].Repos {
+			return cloud[a].Repos > cloud[b].Repos
+		}
+		return cloud[a].Topic < cloud[b].Topic
+	})
+	return cloud[:min(top, len(cloud))]
+}
+
+// topicCloudSize é quantos tópicos -topic-cloud mostra.
+const topicCloudSize = 30
+
+// writeTopicCloud escreve a nuvem de tópicos; em text, com barras
+// proporcionais à frequência.
+func writeTopicCloud(w io.Writer, format string, cloud []topicCount) error {
+	switch format {
+	case formatText, formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, tr("TÓPICO\tREPOSITÓRIOS\t"))
+		for _, c := range cloud {
+			bar := ""
+			if format == formatText {
+				bar = strings.Repeat("█", max(1, c.Repos*40/cloud[0].Repos))
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\n", c.Topic, c.Repos, bar)
+		}
+		return tw.Flush()
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"topic", "repos"})
+		for _, c := range cloud {
+			cw.Write([]string{c.Topic, strconv.Itoa(c.Repos)})
+		}
+		cw.Flush()
+		return cw.Error()
+	case formatJSON:
+		return encodeJSON(w, cloud)
+	}
+	return fmt.Errorf(tr("formato desconhecido: %q (use text, table, csv ou json)"), format)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// unlabeled agrupa as issues sem nenhum label.
+const unlabeled = "(sem label)"
+
+// labelStats resume as issues abertas de um label.
+type labelStats struct {
+	Label         string  `json:"label"`
+	Open          int     `json:"open"`
+	MedianAgeDays float64 `json:"median_age_days"`
+	OldestNumber  int     `json:"oldest_number"`
+	OldestAgeDays int     `json:"oldest_age_days"`
+}
+
+// runIssuesReport implementa `issues-report owner/repo`.
+func runIssuesReport(args []string) error {
+	fs := newFlagSet("issues-report")
+	limit := fs.Int("limit", maxSearchResults, "máximo de issues analisadas (máx. 1000)")
+	format := fs.String("format", formatTable, "formato de saída: table ou json")
+	cf := addClientFlags(fs)
+
+	// O repositório pode vir antes ou depois das flags.
+	var fullName string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		fullName, args = args[0], args[1:]
+	}
+	fs.Parse(args)
+	if fullName == "" && fs.NArg() > 0 {
+		fullName = fs.Arg(0)
+	}
+	if !strings.Contains(fullName, "/") {
+		return invalid(errors.New(tr("uso: issues-report owner/repo [-limit N]")))
+	}
+	if *limit < 1 || *limit > maxSearchResults {
+		return invalid(fmt.Errorf(tr("-limit deve estar entre 1 e %d"), maxSearchResults))
+	}
+	if *format != formatTable && *format != formatJSON {
+		return invalid(fmt.Errorf(tr("formato desconhecido: %q (use table ou json)"), *format))
+	}
+
+	client, err := cf.newClient()
+	if err != nil {
+		return err
+	}
+
+	opts := SearchOptions{
+		Query:   fmt.Sprintf("repo:%s is:issue state:open", fullName),
+		Sort:    "created",
+		Order:   "asc",
+		PerPage: maxPerPage,
+	}
+	ctx, cancel := cf.context()
+	defer cancel()
+	result, err := client.SearchAllIssues(ctx, opts, *limit)
+	if err != nil {
+		return err
+	}
+
+	stats := triageByLabel(result.Items, time.Now())
+	if *format == formatJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+	fmt.Printf(tr("%s: %d issues abertas (%d analisadas)\n\n"), fullName, result.TotalCount, len(result.Items))
+	return writeTriage(os.Stdout, stats)
+}
+
+// triageByLabel conta as issues e calcula a idade mediana por label.
+// Uma issue com vários labels conta em cada um deles.
+func triageByLabel(issues []Issue, now time.Time) []labelStats {
+	ages := map[string][]int{}
+	oldest := map[string]Issue{}
+	add := func(label string, issue Issue) {
+		ages[label] = append(ages[label], int(now.Sub(issue.CreatedAt).Hours()/24))
+		if o, ok := oldest[label]; !ok || issue.CreatedAt.Before(o.CreatedAt) {
+			oldest[label] = issue
+		}
+	}
+	for _, issue := range issues {
+		if len(issue.Labels) == 0 {
+			add(unlabeled, issue)
+		}
+		for _, l := range issue.Labels {
+			add(l.Name, issue)
+		}
+	}
+
+	stats := make([]labelStats, 0, len(ages))
+	for label, a := range ages {
+		o := oldest[label]
+		stats = append(stats, labelStats{
+			Label:         label,
+			Open:          len(a),
+			MedianAgeDays: median(a),
+			OldestNumber:  o.Number,
+			OldestAgeDays: int(now.Sub(o.CreatedAt).Hours() / 24),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Open != stats[j].Open {
+			return stats[i].Open > stats[j].Open
+		}
+		return stats[i].Label < stats[j].Label
+	})
+	return stats
+}
+
+func writeTriage(w io.Writer, stats []labelStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, tr("LABEL\tABERTAS\tIDADE MEDIANA (DIAS)\tMAIS ANTIGA"))
+	for _, s := range stats {
+		label := s.Label
+		if label == unlabeled {
+			label = tr(label)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.0f\t#%d (%dd)\n", label, s.Open, s.MedianAgeDays, s.OldestNumber, s.OldestAgeDays)
+	}
+	return tw.Flush()
+}
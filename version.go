@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// repoURL identifica o projeto no User-Agent, como pede a documentação
+// da API do GitHub.
+const repoURL = "https://github.com/BunocGomes/ConsumacaoApiGitHub"
+
+// version pode ser definida no build:
+//
+//	go build -ldflags "-X main.version=1.2.0"
+//
+// Sem isso, usamos a versão do módulo registrada pelo Go, se houver.
+var version = ""
+
+// buildVersion devolve a versão do binário e, quando disponível, o
+// commit em que foi compilado.
+func buildVersion() (v, revision string) {
+	v = version
+	info, ok := debug.ReadBuildInfo()
+	if ok {
+		if v == "" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			v = info.Main.Version
+		}
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				revision = s.Value
+			}
+		}
+	}
+	if v == "" {
+		v = "dev"
+	}
+	return v, revision
+}
+
+// defaultUserAgent é o User-Agent usado quando -user-agent não é informado,
+// ex: "ghsearch/1.2.0 (+https://github.com/BunocGomes/ConsumacaoApiGitHub)".
+func defaultUserAgent() string {
+	v, _ := buildVersion()
+	return fmt.Sprintf("%s/%s (+%s)", appName, v, repoURL)
+}
+
+// versionString é o texto impresso por -version.
+func versionString() string {
+	v, revision := buildVersion()
+	s := fmt.Sprintf("%s %s", appName, v)
+	if revision != "" {
+		s += fmt.Sprintf(" (commit %.12s)", revision)
+	}
+	return s
+}
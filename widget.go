@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// maxWidgetItems limita o tamanho de um widget: /widget/top é para listas
+// curtas em blogs e dashboards, não uma segunda /api/search.
+const maxWidgetItems = 30
+
+// widgetItem é um repositório no payload de /widget/top: só o que um widget
+// mostra.
+type widgetItem struct {
+	Name  string `json:"name"`
+	Stars int    `json:"stars"`
+	URL   string `json:"url"`
+}
+
+// widgetResponse é o corpo de /widget/top.
+type widgetResponse struct {
+	Query string       `json:"query"`
+	Items []widgetItem `json:"items"`
+}
+
+// handleWidgetTop responde GET /widget/top?q=...&limit=5 com os
+// repositórios mais estrelados da busca, num JSON mínimo que qualquer
+// página pode buscar direto do navegador (CORS aberto). O token fica no
+// servidor; a resposta leva Cache-Control longo para que navegadores e
+// CDNs segurem as visitas e a quota de busca não acompanhe o tráfego do
+// blog.
+func (s *server) handleWidgetTop(w http.ResponseWriter, r *http.Request) {
+	setWidgetCORS(w)
+	// Erros não vão para o cache: a próxima visita tenta de novo.
+	w.Header().Set("Cache-Control", "no-store")
+
+	q := r.URL.Query()
+	query := q.Get("q")
+	if query == "" {
+		writeHTTPError(w, http.StatusBadRequest, errors.New(tr("parâmetro q é obrigatório")))
+		return
+	}
+	if err := validateQuery(query); err != nil {
+		writeHTTPError(w, http.StatusBadRequest, err)
+		return
+	}
+	limit := 5
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxWidgetItems {
+			writeHTTPError(w, http.StatusBadRequest, fmt.Errorf(tr("limit deve estar entre 1 e %d"), maxWidgetItems))
+			return
+		}
+		limit = n
+	}
+
+	ctx, cancel := withTimeout(r.Context(), s.timeout)
+	defer cancel()
+	opts := SearchOptions{Query: query, Sort: "stars", Order: "desc", PerPage: limit}
+	result, err := s.client.SearchRepositories(ctx, opts)
+	if err != nil {
+		writeHTTPError(w, upstreamStatus(err), err)
+		return
+	}
+
+	resp := widgetResponse{Query: query, Items: make([]widgetItem, 0, limit)}
+	for _, repo := range result.Items[:min(limit, len(result.Items))] {
+		resp.Items = append(resp.Items, widgetItem{Name: repo.FullName, Stars: repo.Stars, URL: repo.URL})
+	}
+	if s.widgetMaxAge > 0 {
+		// stale-while-revalidate deixa a CDN servir a versão antiga
+		// enquanto busca a nova, sem fazer o leitor esperar a API.
+		secs := int(s.widgetMaxAge.Seconds())
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", secs, secs))
+	}
+	writeHTTPJSON(w, http.StatusOK, resp)
+}
+
+// handleWidgetPreflight responde o preflight CORS de /widget/top, para
+// páginas que mandam headers próprios no fetch.
+func handleWidgetPreflight(w http.ResponseWriter, r *http.Request) {
+	setWidgetCORS(w)
+	w.Header().Set("Access-Control-Max-Age", "86400")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setWidgetCORS libera /widget/top para qualquer origem. Só leitura, sem
+// cookies nem credenciais: o que a resposta expõe é o mesmo que a busca
+// pública do GitHub.
+func setWidgetCORS(w http.ResponseWriter) {
+	h := w.Header()
+	h.Set("Access-Control-Allow-Origin", "*")
+	h.Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	h.Set("Access-Control-Allow-Headers", "Content-Type")
+}